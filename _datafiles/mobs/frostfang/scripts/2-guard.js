@@ -22,4 +22,30 @@ function onIdle(mob, room) {
 // Called whenever a mob uses the converse command.
 function onConverse(message, mob, sourceMob, room) {
 
-}
\ No newline at end of file
+}
+// Called when a player does a social such as "salute guard"
+function onSocial(mob, room, eventDetails) {
+
+    if ( (user = GetUser(eventDetails.sourceId)) == null ) {
+        return false;
+    }
+
+    switch (eventDetails.social) {
+        case "salute":
+        case "bow":
+            mob.Command("salute " + user.GetCharacterName(false));
+            return true;
+        case "wave":
+        case "smile":
+            mob.Command("nod " + user.GetCharacterName(false));
+            return true;
+        case "poke":
+        case "slap":
+        case "kick":
+            mob.Command("glare " + user.GetCharacterName(false));
+            mob.Command("say Watch yourself, citizen.");
+            return true;
+    }
+
+    return false;
+}
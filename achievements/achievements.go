@@ -0,0 +1,100 @@
+package achievements
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/volte6/gomud/characters"
+	"github.com/volte6/gomud/fileloader"
+)
+
+const (
+	defaultPoints = 10
+)
+
+var (
+	allAchievements          = map[string]*Achievement{}
+	achievementDataFilesPath = "_datafiles/achievements"
+)
+
+type Achievement struct {
+	AchievementId string   `yaml:"achievementid"`    // Unique id of the achievement ("ratcatcher")
+	Name          string   `yaml:"name"`             // Display name of the achievement
+	Description   string   `yaml:"description"`      // What it takes to unlock it
+	Title         string   `yaml:"title,omitempty"`  // Title the player can wear once unlocked, such as "the Ratcatcher" (optional)
+	Rare          bool     `yaml:"rare,omitempty"`   // Rare achievements are announced to everyone online when unlocked
+	Secret        bool     `yaml:"secret,omitempty"` // Secret achievements aren't listed until unlocked
+	Points        int      `yaml:"points,omitempty"` // Counts toward the achievement leaderboard. Defaults to 10
+	Criteria      Criteria `yaml:"criteria"`         // What must be done to unlock it
+}
+
+func (a *Achievement) Id() string {
+	return a.AchievementId
+}
+
+func (a *Achievement) Filepath() string {
+	return fmt.Sprintf("%s.yaml", a.AchievementId)
+}
+
+func (a *Achievement) Validate() error {
+
+	a.AchievementId = strings.ToLower(a.AchievementId)
+
+	if a.Name == `` {
+		a.Name = a.AchievementId
+	}
+
+	if a.Points == 0 {
+		a.Points = defaultPoints // default
+	}
+
+	if err := a.Criteria.Validate(); err != nil {
+		return fmt.Errorf(`achievement %s: %w`, a.AchievementId, err)
+	}
+
+	return nil
+}
+
+func GetAchievement(achievementId string) *Achievement {
+	if a, ok := allAchievements[strings.ToLower(achievementId)]; ok {
+		return a
+	}
+	return nil
+}
+
+// Returns all achievement id's in a consistent order
+func GetAchievementIds() []string {
+	ids := []string{}
+	for id := range allAchievements {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Adds up the points of every achievement unlocked
+func GetPoints(unlocked characters.Achievements) int {
+	total := 0
+	for achievementId := range unlocked {
+		if a := GetAchievement(achievementId); a != nil {
+			total += a.Points
+		}
+	}
+	return total
+}
+
+func LoadDataFiles() {
+
+	start := time.Now()
+
+	var err error
+	allAchievements, err = fileloader.LoadAllFlatFiles[string, *Achievement](achievementDataFilesPath)
+	if err != nil {
+		panic(err)
+	}
+
+	slog.Info("achievements.LoadDataFiles()", "loadedCount", len(allAchievements), "Time Taken", time.Since(start))
+}
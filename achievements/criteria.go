@@ -0,0 +1,157 @@
+package achievements
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/volte6/gomud/mobs"
+	"github.com/volte6/gomud/quests"
+	"github.com/volte6/gomud/races"
+	"github.com/volte6/gomud/users"
+)
+
+type CriteriaType string
+
+const (
+	CriteriaKills  CriteriaType = `kills`  // Kill Quantity mobs, optionally only of a Race or MobId
+	CriteriaZones  CriteriaType = `zones`  // Visit Quantity different zones, or a specific Zone
+	CriteriaGold   CriteriaType = `gold`   // Earn Quantity gold over a lifetime
+	CriteriaDeaths CriteriaType = `deaths` // Die Quantity times
+	CriteriaQuests CriteriaType = `quests` // Complete Quantity quests, or a specific QuestId
+)
+
+type Criteria struct {
+	Type     CriteriaType `yaml:"type"`
+	Race     string       `yaml:"race,omitempty"`     // Race of mobs to kill
+	MobId    int          `yaml:"mobid,omitempty"`    // Mob to kill
+	Zone     string       `yaml:"zone,omitempty"`     // Zone to explore
+	QuestId  int          `yaml:"questid,omitempty"`  // Quest to complete
+	Quantity int          `yaml:"quantity,omitempty"` // How many. Defaults to 1
+}
+
+func (c *Criteria) Validate() error {
+
+	switch c.Type {
+	case CriteriaKills, CriteriaZones, CriteriaGold, CriteriaDeaths, CriteriaQuests:
+	default:
+		return fmt.Errorf(`unknown criteria type: %s`, c.Type)
+	}
+
+	if c.Quantity < 1 {
+		c.Quantity = 1 // default
+	}
+
+	// A specific zone or quest is either done or not
+	if (c.Type == CriteriaZones && c.Zone != ``) || (c.Type == CriteriaQuests && c.QuestId != 0) {
+		c.Quantity = 1
+	}
+
+	return nil
+}
+
+// Returns how far along a user is toward meeting the criteria
+func (c *Criteria) Progress(user *users.UserRecord) int {
+
+	progress := 0
+
+	switch c.Type {
+
+	case CriteriaKills:
+
+		if c.Race == `` && c.MobId == 0 {
+			progress = user.Character.KD.GetMobKills()
+			break
+		}
+
+		for mobId, killCt := range user.Character.KD.Kills {
+			if c.MobId != 0 && mobId != c.MobId {
+				continue
+			}
+			if c.Race != `` {
+				mobSpec := mobs.GetMobSpec(mobs.MobId(mobId))
+				if mobSpec == nil {
+					continue
+				}
+				raceInfo := races.GetRace(mobSpec.Character.RaceId)
+				if raceInfo == nil || !strings.EqualFold(raceInfo.Name, c.Race) {
+					continue
+				}
+			}
+			progress += killCt
+		}
+
+	case CriteriaZones:
+
+		if c.Zone == `` {
+			progress = len(user.Character.ZonesVisited)
+			break
+		}
+
+		for zoneName := range user.Character.ZonesVisited {
+			if strings.EqualFold(zoneName, c.Zone) {
+				progress = 1
+			}
+		}
+
+	case CriteriaGold:
+		progress = user.Character.GoldEarned
+
+	case CriteriaDeaths:
+		progress = user.Character.KD.GetDeaths()
+
+	case CriteriaQuests:
+
+		for questId, stepId := range user.Character.QuestProgress {
+			if stepId != `end` {
+				continue
+			}
+			if c.QuestId != 0 {
+				if questId == c.QuestId {
+					progress = 1
+				}
+				continue
+			}
+			if questInfo := quests.GetQuest(quests.PartsToToken(questId, `end`)); questInfo != nil && !questInfo.Secret {
+				progress++
+			}
+		}
+
+	}
+
+	if progress > c.Quantity {
+		progress = c.Quantity
+	}
+
+	return progress
+}
+
+// Unlocks any achievements the user has met the criteria for.
+// Only achievements with the given type of criteria are checked, unless it is empty.
+// Returns the newly unlocked achievements.
+func Check(user *users.UserRecord, criteriaType CriteriaType) []*Achievement {
+
+	unlocked := []*Achievement{}
+
+	for _, achievementId := range GetAchievementIds() {
+
+		a := allAchievements[achievementId]
+
+		if criteriaType != `` && a.Criteria.Type != criteriaType {
+			continue
+		}
+
+		if user.Character.Achievements.Has(a.AchievementId) {
+			continue
+		}
+
+		if a.Criteria.Progress(user) < a.Criteria.Quantity {
+			continue
+		}
+
+		if user.Character.UnlockAchievement(a.AchievementId) {
+			unlocked = append(unlocked, a)
+		}
+	}
+
+	return unlocked
+}
@@ -0,0 +1,326 @@
+package boards
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/volte6/gomud/configs"
+	"github.com/volte6/gomud/util"
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	lock         sync.Mutex
+	loadedBoards = map[string]*Board{}
+
+	validBoardId = regexp.MustCompile(`^[a-z0-9_-]+$`)
+
+	ErrInvalidId  = errors.New(`board ids can only use letters, numbers, - and _`)
+	ErrNoBoard    = errors.New(`board not found`)
+	ErrNoPost     = errors.New(`post not found`)
+	ErrBoardFull  = errors.New(`board is full`)
+	ErrEmptyPost  = errors.New(`post needs a subject and a message`)
+	ErrBadReplyTo = errors.New(`replying to a post that doesn't exist`)
+)
+
+// A single post on a board
+type Post struct {
+	PostId       int       `yaml:"postid"`
+	ReplyTo      int       `yaml:"replyto,omitempty"` // PostId this is a reply to, if any
+	Subject      string    `yaml:"subject"`
+	AuthorUserId int       `yaml:"authoruserid"`
+	AuthorName   string    `yaml:"authorname"`
+	Posted       time.Time `yaml:"posted"`
+	Body         string    `yaml:"body"`
+	ReadBy       []int     `yaml:"readby,omitempty"` // UserIds of everyone who has read it
+}
+
+// A bulletin board that can be placed in one or more rooms.
+// Boards are kept in their own files, so posts survive rooms being unloaded or reset.
+type Board struct {
+	BoardId    string `yaml:"boardid"`
+	Name       string `yaml:"name"`
+	NextPostId int    `yaml:"nextpostid"`
+	Posts      []Post `yaml:"posts,omitempty"`
+}
+
+func (p Post) DateString() string {
+	tFormat := string(configs.GetConfig().TimeFormat)
+	return p.Posted.Format(tFormat)
+}
+
+func (p Post) IsReadBy(userId int) bool {
+	for _, uid := range p.ReadBy {
+		if uid == userId {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *Board) Filepath() string {
+	return util.FilePath(string(configs.GetConfig().FolderBoardData), `/`, b.BoardId+`.yaml`)
+}
+
+// Returns a copy of the post, if it's on the board
+func (b *Board) GetPost(postId int) (Post, bool) {
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	for _, p := range b.Posts {
+		if p.PostId == postId {
+			return p, true
+		}
+	}
+
+	return Post{}, false
+}
+
+// Returns copies of all posts, oldest first
+func (b *Board) GetPosts() []Post {
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	posts := make([]Post, len(b.Posts))
+	copy(posts, b.Posts)
+
+	return posts
+}
+
+// Returns copies of the replies to a post, oldest first
+func (b *Board) Replies(postId int) []Post {
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	replies := []Post{}
+	for _, p := range b.Posts {
+		if p.ReplyTo == postId {
+			replies = append(replies, p)
+		}
+	}
+
+	return replies
+}
+
+// How many posts a user hasn't read yet
+func (b *Board) UnreadCount(userId int) int {
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	ct := 0
+	for _, p := range b.Posts {
+		if !p.IsReadBy(userId) {
+			ct++
+		}
+	}
+
+	return ct
+}
+
+// Returns the oldest post the user hasn't read yet
+func (b *Board) NextUnread(userId int) (Post, bool) {
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	for _, p := range b.Posts {
+		if !p.IsReadBy(userId) {
+			return p, true
+		}
+	}
+
+	return Post{}, false
+}
+
+// Adds a post to the board and saves it. Returns the new post.
+func (b *Board) AddPost(p Post) (Post, error) {
+
+	p.Subject = strings.TrimSpace(p.Subject)
+	p.Body = strings.TrimSpace(p.Body)
+
+	if p.Subject == `` || p.Body == `` {
+		return p, ErrEmptyPost
+	}
+
+	lock.Lock()
+
+	if len(b.Posts) >= int(configs.GetConfig().BoardMaxPosts) {
+		lock.Unlock()
+		return p, ErrBoardFull
+	}
+
+	if p.ReplyTo > 0 {
+		found := false
+		for _, existing := range b.Posts {
+			if existing.PostId == p.ReplyTo {
+				found = true
+				break
+			}
+		}
+		if !found {
+			lock.Unlock()
+			return p, ErrBadReplyTo
+		}
+	}
+
+	b.NextPostId++
+	p.PostId = b.NextPostId
+	p.Posted = time.Now()
+	p.ReadBy = []int{p.AuthorUserId} // Nobody needs to be told about their own post
+
+	b.Posts = append(b.Posts, p)
+
+	lock.Unlock()
+
+	return p, b.save()
+}
+
+// Removes a post and any replies to it, then saves. Returns how many posts were removed.
+func (b *Board) DeletePost(postId int) (int, error) {
+
+	lock.Lock()
+
+	removed := map[int]struct{}{postId: {}}
+	kept := []Post{}
+
+	// Posts are in order, so replies always come after what they reply to
+	for _, p := range b.Posts {
+		if _, ok := removed[p.PostId]; ok {
+			continue
+		}
+		if _, ok := removed[p.ReplyTo]; ok && p.ReplyTo > 0 {
+			removed[p.PostId] = struct{}{}
+			continue
+		}
+		kept = append(kept, p)
+	}
+
+	removedCt := len(b.Posts) - len(kept)
+	b.Posts = kept
+
+	lock.Unlock()
+
+	if removedCt == 0 {
+		return 0, ErrNoPost
+	}
+
+	return removedCt, b.save()
+}
+
+// Records that a user has read a post
+func (b *Board) MarkRead(postId int, userId int) {
+
+	lock.Lock()
+
+	changed := false
+	for i, p := range b.Posts {
+		if p.PostId == postId && !p.IsReadBy(userId) {
+			b.Posts[i].ReadBy = append(b.Posts[i].ReadBy, userId)
+			changed = true
+			break
+		}
+	}
+
+	lock.Unlock()
+
+	if changed {
+		if err := b.save(); err != nil {
+			slog.Error("boards.MarkRead()", "boardId", b.BoardId, "error", err)
+		}
+	}
+}
+
+func (b *Board) save() error {
+
+	lock.Lock()
+	bytes, err := yaml.Marshal(b)
+	lock.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	return util.Save(b.Filepath(), bytes, bool(configs.GetConfig().CarefulSaveFiles))
+}
+
+// Returns a board, loading it from disk if it isn't in memory yet
+func Get(boardId string) (*Board, error) {
+
+	boardId = strings.ToLower(boardId)
+
+	if !validBoardId.MatchString(boardId) {
+		return nil, ErrInvalidId
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	if b, ok := loadedBoards[boardId]; ok {
+		return b, nil
+	}
+
+	b := &Board{BoardId: boardId}
+
+	bytes, err := os.ReadFile(b.Filepath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNoBoard
+		}
+		return nil, err
+	}
+
+	if err := yaml.Unmarshal(bytes, b); err != nil {
+		return nil, fmt.Errorf(`board %s: %w`, boardId, err)
+	}
+	b.BoardId = boardId
+
+	loadedBoards[boardId] = b
+
+	return b, nil
+}
+
+// Makes a new, empty board. If it already exists, it is returned as is.
+func Create(boardId string, name string) (*Board, error) {
+
+	if b, err := Get(boardId); err != ErrNoBoard {
+		return b, err
+	}
+
+	b := &Board{
+		BoardId: strings.ToLower(boardId),
+		Name:    name,
+	}
+
+	if err := os.MkdirAll(util.FilePath(string(configs.GetConfig().FolderBoardData)), 0755); err != nil {
+		return nil, err
+	}
+
+	if err := b.save(); err != nil {
+		return nil, err
+	}
+
+	lock.Lock()
+	loadedBoards[b.BoardId] = b
+	lock.Unlock()
+
+	return b, nil
+}
+
+// Drops a board from memory. Everything is saved as it changes, so nothing is lost.
+func Unload(boardId string) {
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	delete(loadedBoards, strings.ToLower(boardId))
+}
@@ -0,0 +1,77 @@
+package boards
+
+import (
+	"os"
+	"testing"
+)
+
+func TestBoardPosts(t *testing.T) {
+
+	// Boards are saved under a relative path, so keep them out of the repo
+	wd, _ := os.Getwd()
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	if _, err := Create(`../nope`, `Bad`); err != ErrInvalidId {
+		t.Errorf("Create() with a bad id error = %v, want %v", err, ErrInvalidId)
+	}
+
+	b, err := Create(`test`, `Test Board`)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if _, err := b.AddPost(Post{Subject: `Empty`}); err != ErrEmptyPost {
+		t.Errorf("AddPost() with no body error = %v, want %v", err, ErrEmptyPost)
+	}
+
+	first, err := b.AddPost(Post{Subject: `Hello`, Body: `First post`, AuthorUserId: 1})
+	if err != nil || first.PostId != 1 {
+		t.Fatalf("AddPost() = %d, %v, want 1, nil", first.PostId, err)
+	}
+
+	reply, err := b.AddPost(Post{ReplyTo: first.PostId, Subject: `Re: Hello`, Body: `A reply`, AuthorUserId: 2})
+	if err != nil || reply.PostId != 2 {
+		t.Fatalf("AddPost() reply = %d, %v, want 2, nil", reply.PostId, err)
+	}
+
+	if _, err := b.AddPost(Post{ReplyTo: 99, Subject: `Re: ?`, Body: `Lost`, AuthorUserId: 2}); err != ErrBadReplyTo {
+		t.Errorf("AddPost() to a missing post error = %v, want %v", err, ErrBadReplyTo)
+	}
+
+	// Authors have already read their own posts
+	if got := b.UnreadCount(1); got != 1 {
+		t.Errorf("UnreadCount(1) = %d, want 1", got)
+	}
+	b.MarkRead(reply.PostId, 1)
+	if got := b.UnreadCount(1); got != 0 {
+		t.Errorf("UnreadCount(1) after MarkRead = %d, want 0", got)
+	}
+	if next, ok := b.NextUnread(3); !ok || next.PostId != first.PostId {
+		t.Errorf("NextUnread(3) = %d, %v, want %d, true", next.PostId, ok, first.PostId)
+	}
+
+	// Posts should survive being dropped from memory
+	Unload(`test`)
+	if b, err = Get(`test`); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got := len(b.Replies(first.PostId)); got != 1 {
+		t.Errorf("Replies() after reload = %d, want 1", got)
+	}
+
+	// Taking down a post takes down its replies
+	if removed, err := b.DeletePost(first.PostId); err != nil || removed != 2 {
+		t.Errorf("DeletePost() = %d, %v, want 2, nil", removed, err)
+	}
+	if _, err := b.DeletePost(first.PostId); err != ErrNoPost {
+		t.Errorf("DeletePost() again error = %v, want %v", err, ErrNoPost)
+	}
+
+	// Ids aren't reused
+	if p, _ := b.AddPost(Post{Subject: `Again`, Body: `Another`, AuthorUserId: 1}); p.PostId != 3 {
+		t.Errorf("AddPost() after delete = %d, want 3", p.PostId)
+	}
+}
@@ -0,0 +1,333 @@
+package channels
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/volte6/gomud/chatfilter"
+	"github.com/volte6/gomud/chatlog"
+	"github.com/volte6/gomud/discord"
+	"github.com/volte6/gomud/events"
+	"github.com/volte6/gomud/fileloader"
+	"github.com/volte6/gomud/users"
+)
+
+const (
+	// Clients that enable this GMCP module are sent channel messages and lists
+	GMCPModule = `Comm.Channel`
+)
+
+var (
+	allChannels          = map[string]*Channel{}
+	channelDataFilesPath = "_datafiles/channels"
+
+	lock        sync.Mutex
+	history     = map[string][]HistoryEntry{}      // Recent messages, by history key
+	recentSends = map[int]map[string][]time.Time{} // When each user last talked on each channel
+
+	ErrNotAllowed  = errors.New(`not allowed on channel`)
+	ErrNotJoined   = errors.New(`channel not joined`)
+	ErrMuted       = errors.New(`muted on channel`)
+	ErrLevelTooLow = errors.New(`level too low for channel`)
+	ErrRateLimited = errors.New(`talking too often on channel`)
+)
+
+type Channel struct {
+	ChannelId   string `yaml:"channelid"`            // Unique id of the channel, also the command used to talk on it ("newbie")
+	Name        string `yaml:"name"`                 // Name shown to players ("Newbie")
+	Description string `yaml:"description"`          // What the channel is for
+	Color       string `yaml:"color,omitempty"`      // Color or ansi alias used for messages on the channel
+	Permission  string `yaml:"permission,omitempty"` // If set to "mod" or "admin", only those users can join
+	MinLevel    int    `yaml:"minlevel,omitempty"`   // Lowest character level that can talk on the channel
+	Clan        bool   `yaml:"clan,omitempty"`       // If true, players only hear others from their own clan
+	AutoJoin    bool   `yaml:"autojoin,omitempty"`   // Whether players are on the channel until they leave it
+	History     int    `yaml:"history,omitempty"`    // How many recent messages are replayed to players who join
+	RateLimit   int    `yaml:"ratelimit,omitempty"`  // Most messages a player can send per minute. 0 means no limit
+}
+
+type HistoryEntry struct {
+	When time.Time
+	Text string // Fully formatted message, as it was sent
+}
+
+// Sent with the Comm.Channel.Text GMCP module
+type GMCPText struct {
+	Channel string `json:"channel"`
+	Talker  string `json:"talker"`
+	Text    string `json:"text"`
+}
+
+// Sent with the Comm.Channel.List GMCP module
+type GMCPListEntry struct {
+	Name    string `json:"name"`
+	Caption string `json:"caption"`
+	Command string `json:"command"`
+}
+
+func (c *Channel) Id() string {
+	return c.ChannelId
+}
+
+func (c *Channel) Filepath() string {
+	return fmt.Sprintf("%s.yaml", c.ChannelId)
+}
+
+func (c *Channel) Validate() error {
+
+	c.ChannelId = strings.ToLower(c.ChannelId)
+
+	if c.Name == `` {
+		c.Name = c.ChannelId
+	}
+
+	if c.Color == `` {
+		c.Color = `channel-` + c.ChannelId // default
+	}
+
+	if c.Permission != `` && c.Permission != users.PermissionMod && c.Permission != users.PermissionAdmin {
+		return fmt.Errorf(`channel %s: unknown permission: %s`, c.ChannelId, c.Permission)
+	}
+
+	if c.History < 0 {
+		c.History = 0
+	}
+
+	return nil
+}
+
+// Returns an error if the user isn't allowed on the channel at all
+func (c *Channel) CanJoin(u *users.UserRecord) error {
+
+	if c.Permission == users.PermissionAdmin && u.Permission != users.PermissionAdmin {
+		return ErrNotAllowed
+	}
+
+	if c.Permission == users.PermissionMod && u.Permission != users.PermissionAdmin && u.Permission != users.PermissionMod {
+		return ErrNotAllowed
+	}
+
+	if c.Clan && u.Character.ClanTag == `` {
+		return ErrNotAllowed
+	}
+
+	return nil
+}
+
+// Whether the user has joined the channel, even if they muted it
+func (c *Channel) IsJoined(u *users.UserRecord) bool {
+	return c.CanJoin(u) == nil && u.GetChannelState(c.ChannelId, c.AutoJoin) != users.ChannelLeft
+}
+
+// Whether the user is shown messages on the channel
+func (c *Channel) IsListening(u *users.UserRecord) bool {
+	return c.CanJoin(u) == nil && u.GetChannelState(c.ChannelId, c.AutoJoin) == users.ChannelJoined
+}
+
+// Clan channels keep a separate history for each clan
+func (c *Channel) historyKey(clanTag string) string {
+	if c.Clan {
+		return c.ChannelId + `:` + strings.ToLower(clanTag)
+	}
+	return c.ChannelId
+}
+
+// Sends a message from a player to everyone listening on the channel
+func (c *Channel) Send(from *users.UserRecord, text string) error {
+
+	if err := c.CanJoin(from); err != nil {
+		return err
+	}
+
+	if !c.IsJoined(from) {
+		return ErrNotJoined
+	}
+
+	if from.IsMutedOn(c.ChannelId) {
+		return ErrMuted
+	}
+
+	if from.Character.Level < c.MinLevel {
+		return ErrLevelTooLow
+	}
+
+	if !c.trackSend(from.UserId) {
+		return ErrRateLimited
+	}
+
+	fromIsMod := from.Permission == users.PermissionAdmin || from.Permission == users.PermissionMod
+
+	c.deliver(from.UserId, from.Character.Name, text, fromIsMod, from.Character.ClanTag)
+
+	discord.Mirror(c.ChannelId, from.Character.Name, text)
+
+	chatlog.Log(chatlog.Entry{
+		Kind:       c.ChannelId,
+		RoomId:     from.Character.RoomId,
+		FromUserId: from.UserId,
+		FromName:   from.Character.Name,
+		Text:       text,
+	})
+
+	return nil
+}
+
+// Sends a message to everyone listening on the channel on behalf of something that isn't a player,
+// such as an outside relay. It goes through the word lists and deafen like anything a player says.
+func (c *Channel) SendAs(fromName string, text string) {
+
+	text, err := chatfilter.CheckWords(text)
+	if err != nil {
+		slog.Info("Channel", "channel", c.ChannelId, "from", fromName, "blocked", err)
+		return
+	}
+
+	c.deliver(0, fromName, text, false, ``)
+}
+
+func (c *Channel) deliver(fromUserId int, fromName string, text string, fromIsMod bool, clanTag string) {
+
+	msg := fmt.Sprintf(`<ansi fg="channel-name">(%s)</ansi> <ansi fg="username">%s</ansi>: <ansi fg="%s">%s</ansi>`, strings.ToLower(c.Name), fromName, c.Color, text)
+
+	c.addHistory(clanTag, msg)
+
+	for _, u := range users.GetAllActiveUsers() {
+
+		if c.Clan && (clanTag == `` || !strings.EqualFold(u.Character.ClanTag, clanTag)) {
+			continue
+		}
+
+		if !c.IsListening(u) {
+			continue
+		}
+
+		if u.UserId != fromUserId && !fromIsMod && u.IsDeafenedOn(c.ChannelId) {
+			continue
+		}
+
+		if fromUserId > 0 && u.IsIgnoring(fromUserId) {
+			continue
+		}
+
+		u.SendText(msg)
+
+		if u.ClientSettings().GmcpEnabled(GMCPModule) {
+			events.AddToQueue(events.GMCPOut{
+				UserId:  u.UserId,
+				Module:  GMCPModule + `.Text`,
+				Payload: GMCPText{Channel: c.ChannelId, Talker: fromName, Text: text},
+			})
+		}
+	}
+}
+
+// Returns false if the user has hit the rate limit for the channel
+func (c *Channel) trackSend(userId int) bool {
+
+	if c.RateLimit < 1 {
+		return true
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	if _, ok := recentSends[userId]; !ok {
+		recentSends[userId] = map[string][]time.Time{}
+	}
+
+	recent := []time.Time{}
+	for _, sent := range recentSends[userId][c.ChannelId] {
+		if time.Since(sent) < time.Minute {
+			recent = append(recent, sent)
+		}
+	}
+
+	if len(recent) >= c.RateLimit {
+		recentSends[userId][c.ChannelId] = recent
+		return false
+	}
+
+	recentSends[userId][c.ChannelId] = append(recent, time.Now())
+
+	return true
+}
+
+func (c *Channel) addHistory(clanTag string, msg string) {
+
+	if c.History < 1 {
+		return
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	key := c.historyKey(clanTag)
+
+	history[key] = append(history[key], HistoryEntry{When: time.Now(), Text: msg})
+	if len(history[key]) > c.History {
+		history[key] = history[key][len(history[key])-c.History:]
+	}
+}
+
+// Returns the recent messages the user would have seen on the channel
+func (c *Channel) GetHistory(u *users.UserRecord) []HistoryEntry {
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	return append([]HistoryEntry{}, history[c.historyKey(u.Character.ClanTag)]...)
+}
+
+func Get(channelId string) *Channel {
+	return allChannels[strings.ToLower(channelId)]
+}
+
+// Returns all channels, sorted by id
+func GetAll() []*Channel {
+	ret := []*Channel{}
+	for _, c := range allChannels {
+		ret = append(ret, c)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].ChannelId < ret[j].ChannelId
+	})
+	return ret
+}
+
+// Sends the list of channels a user is on to their client, if it supports it
+func SendGMCPList(u *users.UserRecord) {
+
+	if !u.ClientSettings().GmcpEnabled(GMCPModule) {
+		return
+	}
+
+	list := []GMCPListEntry{}
+	for _, c := range GetAll() {
+		if c.IsJoined(u) {
+			list = append(list, GMCPListEntry{Name: c.ChannelId, Caption: c.Name, Command: c.ChannelId})
+		}
+	}
+
+	events.AddToQueue(events.GMCPOut{
+		UserId:  u.UserId,
+		Module:  GMCPModule + `.List`,
+		Payload: list,
+	})
+}
+
+func LoadDataFiles() {
+
+	start := time.Now()
+
+	var err error
+	allChannels, err = fileloader.LoadAllFlatFiles[string, *Channel](channelDataFilesPath)
+	if err != nil {
+		panic(err)
+	}
+
+	slog.Info("channels.LoadDataFiles()", "loadedCount", len(allChannels), "Time Taken", time.Since(start))
+}
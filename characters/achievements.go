@@ -0,0 +1,28 @@
+package characters
+
+import "time"
+
+// Achievement id => when it was unlocked
+type Achievements map[string]time.Time
+
+func (a Achievements) Has(achievementId string) bool {
+	_, ok := a[achievementId]
+	return ok
+}
+
+// Records an achievement as unlocked.
+// Returns false if it was already unlocked.
+func (c *Character) UnlockAchievement(achievementId string) bool {
+
+	if c.Achievements == nil {
+		c.Achievements = make(Achievements)
+	}
+
+	if c.Achievements.Has(achievementId) {
+		return false
+	}
+
+	c.Achievements[achievementId] = time.Now()
+
+	return true
+}
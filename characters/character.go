@@ -11,7 +11,9 @@ import (
 
 	"github.com/volte6/gomud/buffs"
 	"github.com/volte6/gomud/configs"
+	"github.com/volte6/gomud/gametime"
 	"github.com/volte6/gomud/items"
+	"github.com/volte6/gomud/moons"
 	"github.com/volte6/gomud/pets"
 	"github.com/volte6/gomud/quests"
 	"github.com/volte6/gomud/races"
@@ -75,16 +77,29 @@ type Character struct {
 	Cooldowns       Cooldowns         `yaml:"cooldowns,omitempty"`     // How many rounds until it is cooled down
 	Settings        map[string]string `yaml:"settings,omitempty"`      // custom setting tracking, used for anything.
 	QuestProgress   map[int]string    `yaml:"questprogress,omitempty"` // quest progress tracking
+	QuestCounters   map[int][]int     `yaml:"questcounters,omitempty"` // progress toward the objectives of the current step of each quest
+	QuestFinished   map[int]uint64    `yaml:"questfinished,omitempty"` // round each repeatable quest was last completed
+	QuestBranches   map[int]string    `yaml:"questbranches,omitempty"` // which branch was chosen in each quest that offered a choice
+	QuestLocked     map[int]bool      `yaml:"questlocked,omitempty"`   // quests closed off by a branch chosen in another quest
 	KeyRing         map[string]string `yaml:"keyring,omitempty"`       // key is the lock id, value is the sequence
 	KD              KDStats           `yaml:"kd,omitempty"`            // Kill/Death stats
+	PvP             PvPStats          `yaml:"pvp,omitempty"`           // Ranked arena record
+	Reputation      map[string]int    `yaml:"reputation,omitempty"`    // Standing with each faction, by faction id
+	Achievements    Achievements      `yaml:"achievements,omitempty"`  // Achievements unlocked, and when
+	Title           string            `yaml:"title,omitempty"`         // Title shown after their name, earned from an achievement
+	ClanTag         string            `yaml:"clantag,omitempty"`       // Tag of the clan the character belongs to, such as "QC"
+	GoldEarned      int               `yaml:"goldearned,omitempty"`    // Lifetime total of gold looted, earned or rewarded
+	ZonesVisited    map[string]bool   `yaml:"zonesvisited,omitempty"`  // Every zone the character has set foot in
 	MiscData        map[string]any    `yaml:"miscdata,omitempty"`      // Any random other data that needs to be stored
 	ExtraLives      int               `yaml:"extralives,omitempty"`    // How many lives remain. If enabled, players can perma-die if they die at zero
 	MobMastery      MobMasteries      `yaml:"mobmastery,omitempty"`    // Tracks particular masteries around a given mob
 	Pet             pets.Pet          `yaml:"pet,omitempty"`           // Do they have a pet?
+	StabledPets     []pets.Pet        `yaml:"stabledpets,omitempty"`   // Pets left in the care of a stable
 	Created         time.Time         `yaml:"created"`                 // When this character was created
 	roomHistory     []int             // A stack FILO of the last X rooms the character has been in
 	followers       []int             // everyone following this user
 	permaBuffIds    []int             // Buff Id's that are always present for this character
+	itemSetBonuses  []items.SetBonus  // Set bonuses earned by worn equipment, updated by RecalculateStats()
 }
 
 func New() *Character {
@@ -195,7 +210,11 @@ func (c *Character) GetBaseCastSuccessChance(spellId string) int {
 }
 
 func (c *Character) CarryCapacity() int {
-	return 5 + int(math.Floor(float64(c.Stats.Strength.ValueAdj/3)))
+	capacity := 5 + int(math.Floor(float64(c.Stats.Strength.ValueAdj/3)))
+	if c.Pet.IsRidden() {
+		capacity += c.Pet.CarryBonus
+	}
+	return capacity
 }
 
 func (c *Character) DeductActionPoints(amount int) bool {
@@ -672,6 +691,8 @@ func (c *Character) getFormattedName(viewingUserId int, uType string, renderFlag
 		f.PetName = c.Pet.DisplayName()
 	}
 
+	f.Title = c.Title
+
 	return f
 }
 
@@ -980,12 +1001,35 @@ func (c *Character) RememberRoom(roomId int) {
 	c.roomHistory = append(c.roomHistory, roomId)
 }
 
+// Records setting foot in a zone.
+// Returns true if it's the first time they've been there.
+func (c *Character) VisitZone(zone string) bool {
+
+	if zone == `` {
+		return false
+	}
+
+	if c.ZonesVisited == nil {
+		c.ZonesVisited = make(map[string]bool)
+	}
+
+	if c.ZonesVisited[zone] {
+		return false
+	}
+
+	c.ZonesVisited[zone] = true
+
+	return true
+}
+
 func (c *Character) IsQuestDone(questToken string) bool {
 	testQuestId, _ := quests.TokenToParts(questToken)
 	if c.QuestProgress == nil {
 		c.QuestProgress = make(map[int]string)
 	}
 
+	c.checkQuestReset(testQuestId)
+
 	stage := c.QuestProgress[testQuestId]
 
 	return stage == `end`
@@ -999,6 +1043,8 @@ func (c *Character) HasQuest(questToken string) bool {
 
 	testQuestId, testQuestStep := quests.TokenToParts(questToken)
 
+	c.checkQuestReset(testQuestId)
+
 	currentStep, ok := c.QuestProgress[testQuestId]
 	if !ok {
 		return false
@@ -1021,6 +1067,10 @@ func (c *Character) GetQuestProgress() map[int]string {
 		c.QuestProgress = make(map[int]string)
 	}
 
+	for questId := range c.QuestProgress {
+		c.checkQuestReset(questId)
+	}
+
 	retMap := make(map[int]string)
 	for questId, stepName := range c.QuestProgress {
 		retMap[questId] = stepName
@@ -1035,18 +1085,76 @@ func (c *Character) GiveQuestToken(questToken string) bool {
 	}
 
 	questId, newStep := quests.TokenToParts(questToken)
+
+	if c.QuestLocked[questId] {
+		return false
+	}
+
+	c.checkQuestReset(questId)
+
 	currentProgress := c.QuestProgress[questId]
 
+	// Quests are handed out by boards, items, scripts and mobs, so starting one is checked here
+	if currentProgress == `` && !c.MeetsQuestRequirements(questId) {
+		return false
+	}
+
 	currentToken := quests.PartsToToken(questId, currentProgress)
 
 	if quests.IsTokenAfter(currentToken, questToken) {
 		c.QuestProgress[questId] = newStep
+		delete(c.QuestCounters, questId) // New step, new objectives
+
+		// Remember when repeatable quests were finished so they can be done again later
+		if newStep == `end` {
+			if questInfo := quests.GetQuest(questToken); questInfo != nil && questInfo.IsRepeatable() {
+				if c.QuestFinished == nil {
+					c.QuestFinished = make(map[int]uint64)
+				}
+				c.QuestFinished[questId] = util.GetRoundCount()
+			}
+		}
+
 		return true
 	}
 
 	return false
 }
 
+// Returns the round a finished repeatable quest becomes available again.
+// Returns zero if the quest isn't repeatable or hasn't been finished.
+func (c *Character) GetQuestResetRound(questId int) uint64 {
+
+	completedRound, ok := c.QuestFinished[questId]
+	if !ok {
+		return 0
+	}
+
+	questInfo := quests.GetQuest(quests.PartsToToken(questId, `end`))
+	if questInfo == nil || !questInfo.IsRepeatable() {
+		return 0
+	}
+
+	return gametime.GetDate(completedRound).AddPeriod(questInfo.Repeatable)
+}
+
+// Forgets a finished repeatable quest once it is available again, so that it can be started over.
+func (c *Character) checkQuestReset(questId int) {
+
+	if c.QuestProgress[questId] != `end` {
+		return
+	}
+
+	resetRound := c.GetQuestResetRound(questId)
+	if resetRound == 0 || util.GetRoundCount() < resetRound {
+		return
+	}
+
+	delete(c.QuestProgress, questId)
+	delete(c.QuestCounters, questId)
+	delete(c.QuestBranches, questId)
+}
+
 func (c *Character) ClearQuestToken(questToken string) {
 
 	if c.QuestProgress == nil {
@@ -1056,6 +1164,275 @@ func (c *Character) ClearQuestToken(questToken string) {
 	questId, _ := quests.TokenToParts(questToken)
 
 	delete(c.QuestProgress, questId)
+	delete(c.QuestCounters, questId)
+	delete(c.QuestFinished, questId)
+	delete(c.QuestBranches, questId)
+}
+
+// Whether a quest was closed off by a branch chosen in another quest.
+func (c *Character) IsQuestLocked(questToken string) bool {
+	questId, _ := quests.TokenToParts(questToken)
+	return c.QuestLocked[questId]
+}
+
+// Whether the character meets the level, prior quest and reputation requirements to start a quest.
+func (c *Character) MeetsQuestRequirements(questId int) bool {
+
+	questInfo := quests.GetQuest(quests.PartsToToken(questId, `start`))
+	if questInfo == nil {
+		return false
+	}
+
+	if c.QuestLocked[questId] {
+		return false
+	}
+
+	if c.Level < questInfo.MinLevel {
+		return false
+	}
+
+	for _, requiredQuestId := range questInfo.Requires {
+		if !c.IsQuestDone(quests.PartsToToken(requiredQuestId, `end`)) {
+			return false
+		}
+	}
+
+	for factionId, minReputation := range questInfo.Reputation {
+		if c.GetReputation(factionId) < minReputation {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Returns the branch chosen in a quest, if any.
+func (c *Character) GetQuestBranch(questId int) (quests.QuestBranch, bool) {
+
+	branchId, ok := c.QuestBranches[questId]
+	if !ok {
+		return quests.QuestBranch{}, false
+	}
+
+	questInfo := quests.GetQuest(quests.PartsToToken(questId, `all+`))
+	if questInfo == nil {
+		return quests.QuestBranch{}, false
+	}
+
+	for _, step := range questInfo.Steps {
+		if branch := step.GetBranch(branchId); branch != nil {
+			return *branch, true
+		}
+	}
+
+	return quests.QuestBranch{}, false
+}
+
+// Returns the branches offered at the current step of a quest, if a choice hasn't been made yet.
+func (c *Character) GetPendingQuestBranches(questId int) (quests.QuestStep, bool) {
+
+	if _, ok := c.QuestBranches[questId]; ok {
+		return quests.QuestStep{}, false
+	}
+
+	stepId, ok := c.QuestProgress[questId]
+	if !ok {
+		return quests.QuestStep{}, false
+	}
+
+	questInfo := quests.GetQuest(quests.PartsToToken(questId, stepId))
+	if questInfo == nil {
+		return quests.QuestStep{}, false
+	}
+
+	step := questInfo.GetStep(stepId)
+	if step == nil || len(step.Branches) == 0 {
+		return quests.QuestStep{}, false
+	}
+
+	return *step, true
+}
+
+// Records the branch chosen at the current step of a quest, and closes off any quests it excludes.
+// Excluded quests that were underway are dropped, and their ids returned.
+func (c *Character) ChooseQuestBranch(questId int, branchId string) (branch quests.QuestBranch, droppedQuestIds []int, ok bool) {
+
+	step, ok := c.GetPendingQuestBranches(questId)
+	if !ok {
+		return quests.QuestBranch{}, nil, false
+	}
+
+	chosen := step.GetBranch(branchId)
+	if chosen == nil {
+		return quests.QuestBranch{}, nil, false
+	}
+
+	if c.QuestBranches == nil {
+		c.QuestBranches = make(map[int]string)
+	}
+	c.QuestBranches[questId] = chosen.Id
+
+	if c.QuestLocked == nil {
+		c.QuestLocked = make(map[int]bool)
+	}
+
+	droppedQuestIds = []int{}
+	for _, excludedId := range chosen.Excludes {
+
+		if excludedId == questId {
+			continue
+		}
+
+		c.QuestLocked[excludedId] = true
+
+		if stepId, ok := c.QuestProgress[excludedId]; ok && stepId != `end` {
+			delete(c.QuestProgress, excludedId)
+			delete(c.QuestCounters, excludedId)
+			droppedQuestIds = append(droppedQuestIds, excludedId)
+		}
+	}
+
+	if chosen.Alignment != 0 {
+		c.UpdateAlignment(chosen.Alignment)
+	}
+
+	return *chosen, droppedQuestIds, true
+}
+
+// Records something the character did toward the objectives of their current quest steps.
+// Returns the ids of any quests that had a matching objective.
+func (c *Character) RecordQuestObjective(objType quests.ObjectiveType, mobId int, itemId int, roomId int) []int {
+
+	questIds := []int{}
+
+	for questId, stepId := range c.QuestProgress {
+
+		questInfo := quests.GetQuest(quests.PartsToToken(questId, stepId))
+		if questInfo == nil {
+			continue
+		}
+
+		step := questInfo.GetStep(stepId)
+		if step == nil || len(step.Objectives) == 0 {
+			continue
+		}
+
+		matched := false
+		for i, objective := range step.Objectives {
+
+			if !objective.Matches(objType, mobId, itemId, roomId) {
+				continue
+			}
+
+			matched = true
+
+			// Collected items are counted from the backpack, not tracked
+			if objective.Type == quests.ObjectiveCollect {
+				continue
+			}
+
+			if c.QuestCounters == nil {
+				c.QuestCounters = make(map[int][]int)
+			}
+
+			counters := c.QuestCounters[questId]
+			if len(counters) != len(step.Objectives) {
+				counters = make([]int, len(step.Objectives))
+			}
+
+			if counters[i] < objective.Quantity {
+				counters[i]++
+			}
+
+			c.QuestCounters[questId] = counters
+		}
+
+		if matched {
+			questIds = append(questIds, questId)
+		}
+	}
+
+	return questIds
+}
+
+// Returns how far along the character is on each objective of their current step of a quest.
+// sharedItems are held by party members nearby, and count toward collecting along with the backpack.
+func (c *Character) GetQuestObjectiveProgress(questId int, sharedItems ...items.Item) []int {
+
+	stepId, ok := c.QuestProgress[questId]
+	if !ok {
+		return []int{}
+	}
+
+	questInfo := quests.GetQuest(quests.PartsToToken(questId, stepId))
+	if questInfo == nil {
+		return []int{}
+	}
+
+	step := questInfo.GetStep(stepId)
+	if step == nil {
+		return []int{}
+	}
+
+	counters := c.QuestCounters[questId]
+
+	progress := make([]int, len(step.Objectives))
+	for i, objective := range step.Objectives {
+
+		if objective.Type == quests.ObjectiveCollect {
+			for _, itm := range c.Items {
+				if itm.ItemId == objective.ItemId {
+					progress[i]++
+				}
+			}
+			for _, itm := range sharedItems {
+				if itm.ItemId == objective.ItemId {
+					progress[i]++
+				}
+			}
+		} else if i < len(counters) {
+			progress[i] = counters[i]
+		}
+
+		if progress[i] > objective.Quantity {
+			progress[i] = objective.Quantity
+		}
+	}
+
+	return progress
+}
+
+// Returns the quest token for the next step of a quest if the character has met
+// every objective of their current step. Steps without objectives are left to scripts.
+func (c *Character) GetCompletedQuestStep(questId int, sharedItems ...items.Item) (nextQuestToken string, ok bool) {
+
+	stepId, ok := c.QuestProgress[questId]
+	if !ok {
+		return ``, false
+	}
+
+	questInfo := quests.GetQuest(quests.PartsToToken(questId, stepId))
+	if questInfo == nil {
+		return ``, false
+	}
+
+	step := questInfo.GetStep(stepId)
+	if step == nil || len(step.Objectives) == 0 {
+		return ``, false
+	}
+
+	nextStepId := questInfo.NextStepId(stepId)
+	if nextStepId == `` {
+		return ``, false
+	}
+
+	for i, amount := range c.GetQuestObjectiveProgress(questId, sharedItems...) {
+		if amount < step.Objectives[i].Quantity {
+			return ``, false
+		}
+	}
+
+	return quests.PartsToToken(questId, nextStepId), true
 }
 
 func (c *Character) SetAggroRemote(exitName string, userId int, mobInstanceId int, aggroType AggroType, roundsWaitTime ...int) {
@@ -1213,7 +1590,10 @@ func (c *Character) BarterPrice(startPrice int) int {
 	if factor > .75 {
 		factor = .75
 	}
-	return int(factor * float64(startPrice))
+	if factor < 0 {
+		factor = 0
+	}
+	return startPrice - int(factor*float64(startPrice))
 }
 
 func (c *Character) XPTNL() int {
@@ -1313,7 +1693,15 @@ func (c *Character) MovementCost() int {
 }
 
 func (c *Character) StatMod(statName string) int {
-	return c.Equipment.StatMod(statName) + c.Buffs.StatMod(statName) + c.Pet.StatMod(statName)
+	return c.Equipment.StatMod(statName) + c.itemSetStatMod(statName) + c.Buffs.StatMod(statName) + c.Pet.StatMod(statName) + moons.StatMod(statName)
+}
+
+func (c *Character) itemSetStatMod(statName string) int {
+	total := 0
+	for _, bonus := range c.itemSetBonuses {
+		total += bonus.StatMods.Get(statName)
+	}
+	return total
 }
 
 func (c *Character) RecalculateStats() {
@@ -1330,6 +1718,9 @@ func (c *Character) RecalculateStats() {
 		c.Stats.Perception.Base = raceInfo.Stats.Perception.Base
 	}
 
+	// Item sets only pay off while enough pieces are worn together
+	c.itemSetBonuses = c.Equipment.ItemSetBonuses()
+
 	// Add any mods for equipment
 	c.Stats.Strength.Mods = c.StatMod(string(statmods.Strength))
 	c.Stats.Speed.Mods = c.StatMod(string(statmods.Speed))
@@ -1434,6 +1825,11 @@ func (c *Character) Validate(recalcPermaBuffs ...bool) error {
 		c.Pet.Validate()
 	}
 
+	for i := range c.StabledPets {
+		c.StabledPets[i].Mounted = false
+		c.StabledPets[i].Validate()
+	}
+
 	if c.SpellBook == nil {
 		c.SpellBook = make(map[string]int)
 	}
@@ -1892,12 +2288,22 @@ func (c *Character) reapplyPermabuffs(removedItems ...items.Item) {
 
 	// Make a list of all item buffs provided by existing worn items
 	for _, itm := range c.GetAllWornItems() {
+		if itm.IsBroken() { // Broken items provide nothing
+			continue
+		}
 		spec := itm.GetSpec()
 		for _, buffId := range spec.WornBuffIds {
 			buffIdCount[buffId] = buffIdCount[buffId] + 1
 		}
 
 	}
+
+	// Add any buffs from item set bonuses
+	for _, bonus := range c.Equipment.ItemSetBonuses() {
+		for _, buffId := range bonus.WornBuffIds {
+			buffIdCount[buffId] = buffIdCount[buffId] + 1
+		}
+	}
 	// Remove any buffs that come specifically from item
 	for _, removedItem := range removedItems {
 		iSpec := removedItem.GetSpec()
@@ -0,0 +1,139 @@
+package characters
+
+import (
+	"github.com/volte6/gomud/items"
+	"github.com/volte6/gomud/util"
+)
+
+const (
+	combatWearChance = 20 // Chance in 100 that a landed hit wears down the gear involved
+	breakTestWear    = 25 // Durability lost when an item fails its break test, such as a shield taking a heavy blow
+)
+
+// Returns every equipment slot, so items can be changed where they are worn
+func (w *Worn) slots() []*items.Item {
+	return []*items.Item{
+		&w.Weapon,
+		&w.Offhand,
+		&w.Head,
+		&w.Neck,
+		&w.Body,
+		&w.Belt,
+		&w.Gloves,
+		&w.Ring,
+		&w.Legs,
+		&w.Feet,
+	}
+}
+
+// Wears down wielded weapons after landing a hit. Crits wear them twice as fast.
+// Returns any items that broke.
+func (c *Character) WearWeapons(crit bool) []items.Item {
+
+	weapons := []*items.Item{&c.Equipment.Weapon}
+	if c.Equipment.Offhand.GetSpec().Type == items.Weapon {
+		weapons = append(weapons, &c.Equipment.Offhand)
+	}
+
+	brokenItems := []items.Item{}
+
+	for _, weapon := range weapons {
+		if weapon.ItemId < 1 || util.Rand(100) >= combatWearChance {
+			continue
+		}
+		if weapon.AddWear(wearAmount(crit)) {
+			brokenItems = append(brokenItems, *weapon)
+		}
+	}
+
+	if len(brokenItems) > 0 {
+		c.reapplyPermabuffs()
+	}
+
+	return brokenItems
+}
+
+// Wears down a random piece of worn gear after being hit. Crits wear it twice as fast.
+// Offhand items like shields also take a heavy blow if they fail their break test.
+// Returns any items that broke.
+func (c *Character) WearArmor(crit bool) []items.Item {
+
+	brokenItems := []items.Item{}
+
+	wornGear := []*items.Item{}
+	for _, slot := range c.Equipment.slots() {
+		if slot.ItemId > 0 && slot.GetSpec().Type != items.Weapon {
+			wornGear = append(wornGear, slot)
+		}
+	}
+
+	if len(wornGear) > 0 && util.Rand(100) < combatWearChance {
+		gear := wornGear[util.Rand(len(wornGear))]
+		if gear.AddWear(wearAmount(crit)) {
+			brokenItems = append(brokenItems, *gear)
+		}
+	}
+
+	if offhand := &c.Equipment.Offhand; offhand.ItemId > 0 && !offhand.IsBroken() {
+
+		modifier := 0
+		if crit { // Crits double the chance of breakage for offhand items.
+			modifier = int(offhand.GetSpec().BreakChance)
+		}
+
+		if offhand.BreakTest(modifier) && offhand.AddWear(breakTestWear) {
+			brokenItems = append(brokenItems, *offhand)
+		}
+	}
+
+	if len(brokenItems) > 0 {
+		c.reapplyPermabuffs()
+	}
+
+	return brokenItems
+}
+
+func wearAmount(crit bool) int {
+	if crit {
+		return 2
+	}
+	return 1
+}
+
+// Returns everything worn or carried that has lost some durability
+func (c *Character) GetDamagedItems() []items.Item {
+
+	damaged := []items.Item{}
+
+	for _, itm := range append(c.GetAllWornItems(), c.GetAllBackpackItems()...) {
+		if itm.Wear > 0 && itm.MaxDurability() > 0 {
+			damaged = append(damaged, itm)
+		}
+	}
+
+	return damaged
+}
+
+// Restores durability to an item wherever the character is keeping it.
+// Returns how much was restored.
+func (c *Character) RepairItem(itm items.Item, amount int) int {
+
+	for _, slot := range c.Equipment.slots() {
+		if slot.ItemId > 0 && slot.Equals(itm) {
+			wasBroken := slot.IsBroken()
+			restored := slot.Repair(amount)
+			if wasBroken && restored > 0 {
+				c.reapplyPermabuffs()
+			}
+			return restored
+		}
+	}
+
+	for idx := range c.Items {
+		if c.Items[idx].Equals(itm) {
+			return c.Items[idx].Repair(amount)
+		}
+	}
+
+	return 0
+}
@@ -53,6 +53,7 @@ type FormattedName struct {
 	UseShortAdjectives bool   // Whether to failover to short adjectives
 	QuestAlert         bool   // Whether this mob is relevant to a current quest
 	PetName            string // Name of pet (if any)
+	Title              string // Title earned from an achievement (if any)
 }
 
 func (f FormattedName) String() string {
@@ -64,6 +65,10 @@ func (f FormattedName) String() string {
 
 	output := fmt.Sprintf(`<ansi fg="%s">%s</ansi>`, ansiAlias, f.Name)
 
+	if f.Title != `` {
+		output += fmt.Sprintf(` <ansi fg="title">%s</ansi>`, f.Title)
+	}
+
 	adjectives := f.Adjectives
 
 	shortSuffix := ``
@@ -4,6 +4,9 @@ type KDStats struct {
 	TotalKills  int         `json:"totalkills"`  // Quick tally of kills
 	Kills       map[int]int `json:"kills"`       // map of MobId to count
 	TotalDeaths int         `json:"totaldeaths"` // Quick tally of deaths
+	// Player vs. player, outside of an arena
+	PlayerKills  int `json:"playerkills"`  // Players they've cut down
+	PlayerDeaths int `json:"playerdeaths"` // Times a player has cut them down
 }
 
 func (kd *KDStats) GetKDRatio() float64 {
@@ -44,3 +47,18 @@ func (kd *KDStats) GetDeaths() int {
 func (kd *KDStats) AddDeath() {
 	kd.TotalDeaths++
 }
+
+func (kd *KDStats) GetPvPKDRatio() float64 {
+	if kd.PlayerDeaths == 0 {
+		return float64(kd.PlayerKills)
+	}
+	return float64(kd.PlayerKills) / float64(kd.PlayerDeaths)
+}
+
+func (kd *KDStats) AddPlayerKill() {
+	kd.PlayerKills++
+}
+
+func (kd *KDStats) AddPlayerDeath() {
+	kd.PlayerDeaths++
+}
@@ -0,0 +1,61 @@
+package characters
+
+import "math"
+
+const (
+	PvPStartingRating = 1200 // Everyone starts here before their first ranked match
+	pvpRatingKFactor  = 32   // Max rating change from a single match
+)
+
+// Ranked player vs. player record, rated with the Elo system
+type PvPStats struct {
+	Rating int `yaml:"rating,omitempty"` // 0 means unrated (PvPStartingRating)
+	Wins   int `yaml:"wins,omitempty"`
+	Losses int `yaml:"losses,omitempty"`
+}
+
+func (p *PvPStats) GetRating() int {
+	if p.Rating == 0 {
+		return PvPStartingRating
+	}
+	return p.Rating
+}
+
+func (p *PvPStats) GetMatchCount() int {
+	return p.Wins + p.Losses
+}
+
+// Updates both records after a ranked match.
+// Returns how many rating points the winner gained and the loser lost.
+func RecordPvPResult(winner *PvPStats, loser *PvPStats) (gained int, lost int) {
+
+	winnerRating := winner.GetRating()
+	loserRating := loser.GetRating()
+
+	gained = int(math.Round(pvpRatingKFactor * (1 - eloExpectedScore(winnerRating, loserRating))))
+	lost = int(math.Round(pvpRatingKFactor * eloExpectedScore(loserRating, winnerRating)))
+
+	// An upset or an even match should always be worth something
+	if gained < 1 {
+		gained = 1
+	}
+	if lost < 1 {
+		lost = 1
+	}
+
+	winner.Rating = winnerRating + gained
+	winner.Wins++
+
+	loser.Rating = loserRating - lost
+	if loser.Rating < 1 {
+		loser.Rating = 1
+	}
+	loser.Losses++
+
+	return gained, lost
+}
+
+// Chance (0-1) that a player with rating beats a player with opponentRating
+func eloExpectedScore(rating int, opponentRating int) float64 {
+	return 1 / (1 + math.Pow(10, float64(opponentRating-rating)/400))
+}
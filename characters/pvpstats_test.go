@@ -0,0 +1,41 @@
+package characters
+
+import "testing"
+
+func TestRecordPvPResult(t *testing.T) {
+	tests := []struct {
+		winnerRating   int
+		loserRating    int
+		expectedGained int
+		expectedLost   int
+	}{
+		{0, 0, 16, 16},       // Unrated players start at the same rating
+		{1200, 1200, 16, 16}, // Even match
+		{1400, 1200, 8, 8},   // Favorite wins, small change
+		{1200, 1400, 24, 24}, // Upset, big change
+		{2400, 1000, 1, 1},   // Always worth at least a point
+	}
+
+	for _, test := range tests {
+		winner := PvPStats{Rating: test.winnerRating}
+		loser := PvPStats{Rating: test.loserRating}
+
+		gained, lost := RecordPvPResult(&winner, &loser)
+
+		if gained != test.expectedGained || lost != test.expectedLost {
+			t.Errorf("RecordPvPResult(%d, %d): expected +%d/-%d, got +%d/-%d", test.winnerRating, test.loserRating, test.expectedGained, test.expectedLost, gained, lost)
+		}
+
+		if winner.Wins != 1 || loser.Losses != 1 {
+			t.Errorf("RecordPvPResult(%d, %d): expected 1 win and 1 loss, got %d and %d", test.winnerRating, test.loserRating, winner.Wins, loser.Losses)
+		}
+
+		startRating := test.winnerRating
+		if startRating == 0 {
+			startRating = PvPStartingRating
+		}
+		if winner.GetRating() != startRating+gained {
+			t.Errorf("RecordPvPResult(%d, %d): expected winner rating %d, got %d", test.winnerRating, test.loserRating, startRating+gained, winner.GetRating())
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package characters
+
+import (
+	"github.com/volte6/gomud/factions"
+)
+
+func (c *Character) GetReputation(factionId string) int {
+	return c.Reputation[factionId]
+}
+
+// Changes the character's reputation with a faction.
+// Returns the standing before and after the change.
+func (c *Character) AdjustReputation(factionId string, amount int) (before factions.Standing, after factions.Standing) {
+
+	if c.Reputation == nil {
+		c.Reputation = make(map[string]int)
+	}
+
+	current := c.Reputation[factionId]
+	before = factions.GetStanding(current)
+
+	current += amount
+	if current < factions.ReputationMin {
+		current = factions.ReputationMin
+	} else if current > factions.ReputationMax {
+		current = factions.ReputationMax
+	}
+
+	c.Reputation[factionId] = current
+
+	return before, factions.GetStanding(current)
+}
+
+// Returns how the factions of a group of mobs regard the character.
+// If the groups belong to several factions, the worst standing wins.
+// ok is false if none of the groups belong to a faction.
+func (c *Character) GetFactionStanding(groups []string) (standing factions.Standing, ok bool) {
+
+	standing = factions.StandingHonored
+
+	for _, f := range factions.GetFactionsForGroups(groups) {
+		if factionStanding := factions.GetStanding(c.Reputation[f.FactionId]); factionStanding < standing {
+			standing = factionStanding
+		}
+		ok = true
+	}
+
+	if !ok {
+		return factions.StandingNeutral, false
+	}
+
+	return standing, true
+}
@@ -96,3 +96,53 @@ func (w *Worn) GetAllItems() []items.Item {
 	}
 	return iList
 }
+
+// Returns how many different pieces of each item set are worn, by set id.
+// Broken pieces don't count toward a set.
+func (w *Worn) ItemSetPieces() map[string]int {
+
+	wornIds := map[string][]int{}
+	for _, slot := range w.slots() {
+		if slot.ItemId < 1 || slot.IsBroken() {
+			continue
+		}
+		if itemSet := items.GetItemSetFor(slot.ItemId); itemSet != nil {
+			wornIds[itemSet.ItemSetId] = append(wornIds[itemSet.ItemSetId], slot.ItemId)
+		}
+	}
+
+	pieces := map[string]int{}
+	for itemSetId, itemIds := range wornIds {
+		pieces[itemSetId] = items.GetItemSet(itemSetId).CountPieces(itemIds...)
+	}
+
+	return pieces
+}
+
+// Returns every set bonus earned by what is worn
+func (w *Worn) ItemSetBonuses() []items.SetBonus {
+	bonuses := []items.SetBonus{}
+	for itemSetId, pieces := range w.ItemSetPieces() {
+		bonuses = append(bonuses, items.GetItemSet(itemSetId).ActiveBonuses(pieces)...)
+	}
+	return bonuses
+}
+
+// Returns how many different pieces of an item set the character owns (worn or carried), and how many are worn.
+func (c *Character) ItemSetPieces(itemSet *items.ItemSet) (owned int, worn int) {
+
+	wornIds := []int{}
+	ownedIds := []int{}
+
+	for _, itm := range c.GetAllWornItems() {
+		if !itm.IsBroken() { // Broken pieces don't count toward a set
+			wornIds = append(wornIds, itm.ItemId)
+		}
+		ownedIds = append(ownedIds, itm.ItemId)
+	}
+	for _, itm := range c.GetAllBackpackItems() {
+		ownedIds = append(ownedIds, itm.ItemId)
+	}
+
+	return itemSet.CountPieces(ownedIds...), itemSet.CountPieces(wornIds...)
+}
@@ -0,0 +1,353 @@
+package chatfilter
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/volte6/gomud/configs"
+	"github.com/volte6/gomud/fileloader"
+	"github.com/volte6/gomud/users"
+)
+
+const (
+	strikeWindow  = 10 * time.Minute // How long a strike counts towards an auto mute
+	repeatWindow  = time.Minute      // Repeats further apart than this start counting over
+	maxMuteLength = 24 * time.Hour
+)
+
+var (
+	allWordLists          = map[string]*WordList{}
+	wordListDataFilesPath = "_datafiles/chatfilters"
+
+	lock          sync.Mutex
+	customFilters = []namedFilter{}
+	recentSpeech  = map[int]*speechHistory{}
+
+	ErrBlocked   = errors.New(`message blocked`)
+	ErrRepeated  = errors.New(`message repeated too often`)
+	ErrFlooding  = errors.New(`sending messages too quickly`)
+	ErrAutoMuted = errors.New(`muted for spamming`)
+)
+
+// A list of words or patterns that are replaced or blocked in player text
+type WordList struct {
+	FilterId    string   `yaml:"filterid"`              // Unique id of the list, matches the filename
+	Description string   `yaml:"description,omitempty"` // What the list is for
+	Words       []string `yaml:"words,omitempty"`       // Whole words, matched regardless of case
+	Patterns    []string `yaml:"patterns,omitempty"`    // Regular expressions, for anything a word can't catch
+	Replacement string   `yaml:"replacement,omitempty"` // What a match is replaced with. Empty masks each letter with *
+	Block       bool     `yaml:"block,omitempty"`       // If true, a match stops the message from being sent and counts as a strike
+	regex       *regexp.Regexp
+}
+
+// A filter added from code. It can change the text, or return an error to stop it being sent.
+// Any error counts as a strike towards an auto mute.
+type Filter func(u *users.UserRecord, text string) (string, error)
+
+type namedFilter struct {
+	name   string
+	filter Filter
+}
+
+// What a player has said recently, for spotting spam
+type speechHistory struct {
+	lastText  string
+	lastSent  time.Time
+	repeats   int
+	sent      []time.Time
+	strikes   []time.Time
+	autoMutes int // How many times they have been auto muted, which lengthens the next one
+}
+
+func (w *WordList) Id() string {
+	return w.FilterId
+}
+
+func (w *WordList) Filepath() string {
+	return fmt.Sprintf("%s.yaml", w.FilterId)
+}
+
+func (w *WordList) Validate() error {
+
+	w.FilterId = strings.ToLower(w.FilterId)
+
+	parts := []string{}
+
+	words := []string{}
+	for _, word := range w.Words {
+		if word = strings.TrimSpace(word); word != `` {
+			words = append(words, regexp.QuoteMeta(word))
+		}
+	}
+	if len(words) > 0 {
+		parts = append(parts, `\b(?:`+strings.Join(words, `|`)+`)\b`)
+	}
+
+	for _, pattern := range w.Patterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf(`chat filter %s: bad pattern %s: %w`, w.FilterId, pattern, err)
+		}
+		parts = append(parts, `(?:`+pattern+`)`)
+	}
+
+	if len(parts) == 0 {
+		return fmt.Errorf(`chat filter %s: no words or patterns`, w.FilterId)
+	}
+
+	w.regex = regexp.MustCompile(`(?i)` + strings.Join(parts, `|`))
+
+	return nil
+}
+
+// Replaces anything on the list. Returns false if the text had something that blocks it.
+func (w *WordList) Apply(text string) (string, bool) {
+
+	if !w.regex.MatchString(text) {
+		return text, true
+	}
+
+	if w.Block {
+		return text, false
+	}
+
+	return w.regex.ReplaceAllStringFunc(text, func(match string) string {
+		if w.Replacement != `` {
+			return w.Replacement
+		}
+		return strings.Map(func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				return '*'
+			}
+			return r
+		}, match)
+	}), true
+}
+
+// Adds a filter that runs on player text after the word lists.
+// Adding a filter with the same name replaces it.
+func AddFilter(name string, f Filter) {
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	for i, nf := range customFilters {
+		if nf.name == name {
+			customFilters[i].filter = f
+			return
+		}
+	}
+
+	customFilters = append(customFilters, namedFilter{name, f})
+}
+
+// Runs player text through the filters before anyone else sees it.
+// Returns the text to send, or an error if it shouldn't be sent at all.
+// If the error is ErrAutoMuted the player has been muted.
+func Check(u *users.UserRecord, text string) (string, error) {
+
+	c := configs.GetConfig()
+
+	if !c.ChatFilterEnabled {
+		return text, nil
+	}
+
+	// Admins and mods are trusted to talk freely
+	if u.Permission == users.PermissionAdmin || u.Permission == users.PermissionMod {
+		return text, nil
+	}
+
+	text, err := checkSpam(u.UserId, text, c)
+
+	if err == nil {
+		text, err = checkWordLists(text)
+	}
+
+	if err == nil {
+		text, err = checkCustomFilters(u, text)
+	}
+
+	if err != nil && addStrike(u, c) {
+		return text, ErrAutoMuted
+	}
+
+	return text, err
+}
+
+// Runs text from outside the game, such as a relayed chat message, through the word lists.
+// There's no player behind it to check for spam or give strikes to.
+func CheckWords(text string) (string, error) {
+
+	if !configs.GetConfig().ChatFilterEnabled {
+		return text, nil
+	}
+
+	return checkWordLists(text)
+}
+
+func checkSpam(userId int, text string, c configs.Config) (string, error) {
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	h, ok := recentSpeech[userId]
+	if !ok {
+		h = &speechHistory{}
+		recentSpeech[userId] = h
+	}
+
+	now := time.Now()
+
+	// Too many messages in the last minute
+	h.sent = trimBefore(h.sent, now.Add(-time.Minute))
+	if len(h.sent) >= int(c.SpamMessagesPerMinute) {
+		return text, ErrFlooding
+	}
+
+	// The same thing over and over
+	normalized := strings.ToLower(strings.Join(strings.Fields(text), ` `))
+	if normalized == h.lastText && now.Sub(h.lastSent) < repeatWindow {
+		h.repeats++
+	} else {
+		h.repeats = 1
+	}
+
+	h.lastText = normalized
+	h.lastSent = now
+
+	if h.repeats > int(c.SpamRepeatLimit) {
+		return text, ErrRepeated
+	}
+
+	h.sent = append(h.sent, now)
+
+	return lowerCaps(text, int(c.SpamCapsPercent)), nil
+}
+
+// Quiets a message that is mostly capital letters
+func lowerCaps(text string, capsPercent int) string {
+
+	letters, upper := 0, 0
+	for _, r := range text {
+		if unicode.IsLetter(r) {
+			letters++
+			if unicode.IsUpper(r) {
+				upper++
+			}
+		}
+	}
+
+	// Short messages like "OK" or "LOL" are left alone
+	if letters < 8 || upper*100/letters < capsPercent {
+		return text
+	}
+
+	lowered := []rune(strings.ToLower(text))
+	for i, r := range lowered {
+		if unicode.IsLetter(r) {
+			lowered[i] = unicode.ToUpper(r)
+			break
+		}
+	}
+
+	return string(lowered)
+}
+
+func checkWordLists(text string) (string, error) {
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	for _, w := range allWordLists {
+		var ok bool
+		if text, ok = w.Apply(text); !ok {
+			return text, ErrBlocked
+		}
+	}
+
+	return text, nil
+}
+
+func checkCustomFilters(u *users.UserRecord, text string) (string, error) {
+
+	lock.Lock()
+	filters := make([]namedFilter, len(customFilters))
+	copy(filters, customFilters)
+	lock.Unlock()
+
+	var err error
+	for _, nf := range filters {
+		if text, err = nf.filter(u, text); err != nil {
+			return text, err
+		}
+	}
+
+	return text, nil
+}
+
+// Records a strike against the user, muting them if they've had too many.
+// Each auto mute lasts twice as long as the one before it.
+// Returns true if they were muted.
+func addStrike(u *users.UserRecord, c configs.Config) bool {
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	h, ok := recentSpeech[u.UserId]
+	if !ok {
+		h = &speechHistory{}
+		recentSpeech[u.UserId] = h
+	}
+
+	now := time.Now()
+
+	h.strikes = append(trimBefore(h.strikes, now.Add(-strikeWindow)), now)
+	if len(h.strikes) < int(c.SpamStrikesToMute) {
+		return false
+	}
+
+	muteLength := time.Duration(c.SpamMuteMinutes) * time.Minute
+	for i := 0; i < h.autoMutes && muteLength < maxMuteLength; i++ {
+		muteLength *= 2
+	}
+	if muteLength > maxMuteLength {
+		muteLength = maxMuteLength
+	}
+
+	h.strikes = h.strikes[:0]
+	h.autoMutes++
+
+	u.MuteFor(muteLength)
+
+	slog.Info("chatfilter", "auto-muted", u.Username, "userId", u.UserId, "minutes", muteLength.Minutes())
+
+	return true
+}
+
+func trimBefore(times []time.Time, cutoff time.Time) []time.Time {
+	for len(times) > 0 && times[0].Before(cutoff) {
+		times = times[1:]
+	}
+	return times
+}
+
+func LoadDataFiles() {
+
+	start := time.Now()
+
+	loaded, err := fileloader.LoadAllFlatFiles[string, *WordList](wordListDataFilesPath)
+	if err != nil {
+		panic(err)
+	}
+
+	lock.Lock()
+	allWordLists = loaded
+	lock.Unlock()
+
+	slog.Info("chatfilter.LoadDataFiles()", "loadedCount", len(loaded), "Time Taken", time.Since(start))
+}
@@ -0,0 +1,68 @@
+package chatfilter
+
+import (
+	"testing"
+
+	"github.com/volte6/gomud/configs"
+)
+
+func TestWordListApply(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		list     WordList
+		text     string
+		wantText string
+		wantOk   bool
+	}{
+		{`masked`, WordList{FilterId: `a`, Words: []string{`darn`}}, `Well Darn it`, `Well **** it`, true},
+		{`whole words only`, WordList{FilterId: `b`, Words: []string{`darn`}}, `darning socks`, `darning socks`, true},
+		{`replaced`, WordList{FilterId: `c`, Words: []string{`darn`}, Replacement: `[beep]`}, `darn darn`, `[beep] [beep]`, true},
+		{`pattern`, WordList{FilterId: `d`, Patterns: []string{`d+a+r+n+`}}, `daaarn`, `******`, true},
+		{`blocked`, WordList{FilterId: `e`, Words: []string{`buy gold`}, Block: true}, `BUY GOLD cheap`, `BUY GOLD cheap`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.list.Validate(); err != nil {
+				t.Fatalf("Validate() error = %v", err)
+			}
+			gotText, gotOk := tt.list.Apply(tt.text)
+			if gotText != tt.wantText || gotOk != tt.wantOk {
+				t.Errorf("Apply(%q) = %q, %v, want %q, %v", tt.text, gotText, gotOk, tt.wantText, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestCheckSpam(t *testing.T) {
+
+	c := configs.Config{
+		SpamMessagesPerMinute: 5,
+		SpamRepeatLimit:       2,
+		SpamCapsPercent:       70,
+	}
+
+	if got, err := checkSpam(1, `HELLO EVERYONE`, c); err != nil || got != `Hello everyone` {
+		t.Errorf("checkSpam() caps = %q, %v, want lowered", got, err)
+	}
+
+	if got, err := checkSpam(1, `OK`, c); err != nil || got != `OK` {
+		t.Errorf("checkSpam() short caps = %q, %v, want untouched", got, err)
+	}
+
+	checkSpam(1, `again`, c)
+	if _, err := checkSpam(1, `Again `, c); err != nil {
+		t.Errorf("checkSpam() second repeat error = %v, want nil", err)
+	}
+	if _, err := checkSpam(1, `again`, c); err != ErrRepeated {
+		t.Errorf("checkSpam() third repeat error = %v, want %v", err, ErrRepeated)
+	}
+
+	for i := 0; i < 5; i++ {
+		checkSpam(2, string(rune('a'+i)), c)
+	}
+	if _, err := checkSpam(2, `one too many`, c); err != ErrFlooding {
+		t.Errorf("checkSpam() flood error = %v, want %v", err, ErrFlooding)
+	}
+}
@@ -0,0 +1,241 @@
+package chatlog
+
+import (
+	"bufio"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/natefinch/lumberjack"
+)
+
+// Kinds of communication that are logged. Chat channels use their channel id.
+const (
+	KindSay     = `say`
+	KindShout   = `shout`
+	KindEmote   = `emote`
+	KindWhisper = `whisper`
+	KindTell    = `tell`
+	KindMail    = `mail`
+	KindSign    = `sign`
+	KindBoard   = `board`
+
+	logFileName = `chat.log`
+	recentSize  = 1000 // How many entries are kept in memory for reports
+)
+
+var (
+	lock       sync.Mutex
+	logFolder  string
+	logWriter  *lumberjack.Logger
+	recent     = make([]Entry, 0, recentSize)
+	recentNext = 0
+)
+
+// A single logged communication
+type Entry struct {
+	When       time.Time `json:"when" yaml:"when"`
+	Kind       string    `json:"kind" yaml:"kind"`
+	RoomId     int       `json:"roomid,omitempty" yaml:"roomid,omitempty"` // Where it was said, or where the sender was
+	FromUserId int       `json:"fromuserid" yaml:"fromuserid"`
+	FromName   string    `json:"fromname" yaml:"fromname"`
+	ToUserId   int       `json:"touserid,omitempty" yaml:"touserid,omitempty"` // Only for private communications
+	ToName     string    `json:"toname,omitempty" yaml:"toname,omitempty"`
+	Text       string    `json:"text" yaml:"text"`
+}
+
+// Whether the user sent or received it
+func (e Entry) Involves(userId int) bool {
+	return e.FromUserId == userId || (e.ToUserId != 0 && e.ToUserId == userId)
+}
+
+// Starts writing to a log file in the folder, rotating it as it grows.
+// Rotated files older than maxAgeDays are deleted.
+func Open(folder string, maxAgeDays int) error {
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	if err := os.MkdirAll(filepath.Join(folder, reportFolderName), 0755); err != nil {
+		return err
+	}
+
+	logFolder = folder
+	logWriter = &lumberjack.Logger{
+		Filename: filepath.Join(folder, logFileName),
+		MaxSize:  50, // Megabytes before rotation
+		MaxAge:   maxAgeDays,
+		Compress: false, // Left uncompressed so they can be searched
+	}
+
+	return nil
+}
+
+func Close() {
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	if logWriter != nil {
+		logWriter.Close()
+		logWriter = nil
+	}
+}
+
+// Records a communication
+func Log(e Entry) {
+
+	if e.When.IsZero() {
+		e.When = time.Now()
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	if len(recent) < recentSize {
+		recent = append(recent, e)
+	} else {
+		recent[recentNext] = e
+	}
+	recentNext = (recentNext + 1) % recentSize
+
+	if logWriter == nil {
+		return
+	}
+
+	line, err := json.Marshal(e)
+	if err != nil {
+		slog.Error("chatlog.Log()", "error", err)
+		return
+	}
+
+	if _, err := logWriter.Write(append(line, '\n')); err != nil {
+		slog.Error("chatlog.Log()", "error", err)
+	}
+}
+
+// Returns up to limit of the most recent entries that match, oldest first.
+// Only covers what was logged since the server started.
+func Recent(match func(Entry) bool, limit int) []Entry {
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	found := []Entry{}
+
+	for i := 0; i < len(recent) && len(found) < limit; i++ {
+		// Walk backwards from the newest entry
+		idx := (recentNext - 1 - i + len(recent)) % len(recent)
+		if match(recent[idx]) {
+			found = append(found, recent[idx])
+		}
+	}
+
+	// Put them back in order
+	for i, j := 0, len(found)-1; i < j; i, j = i+1, j-1 {
+		found[i], found[j] = found[j], found[i]
+	}
+
+	return found
+}
+
+// Searches the log files for entries since a given time.
+// Returns up to limit of the most recent entries that match, oldest first.
+func Search(since time.Time, match func(Entry) bool, limit int) ([]Entry, error) {
+
+	lock.Lock()
+	folder := logFolder
+	lock.Unlock()
+
+	if folder == `` {
+		return Recent(func(e Entry) bool { return !e.When.Before(since) && match(e) }, limit), nil
+	}
+
+	files, err := logFiles(folder, since)
+	if err != nil {
+		return nil, err
+	}
+
+	found := []Entry{}
+
+	for _, fPath := range files {
+
+		f, err := os.Open(fPath)
+		if err != nil {
+			return nil, err
+		}
+
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+
+			e := Entry{}
+			if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+				continue
+			}
+
+			if e.When.Before(since) || !match(e) {
+				continue
+			}
+
+			found = append(found, e)
+			if len(found) > limit {
+				found = found[1:]
+			}
+		}
+
+		f.Close()
+	}
+
+	return found, nil
+}
+
+// Returns the log files that could have entries since a given time, oldest first.
+func logFiles(folder string, since time.Time) ([]string, error) {
+
+	dirEntries, err := os.ReadDir(folder)
+	if err != nil {
+		return nil, err
+	}
+
+	type logFile struct {
+		path    string
+		modTime time.Time
+	}
+
+	files := []logFile{}
+	for _, d := range dirEntries {
+
+		// Rotated files are renamed to something like chat-2024-10-26T15-04-05.000.log
+		if d.IsDir() || !strings.HasPrefix(d.Name(), `chat`) || !strings.HasSuffix(d.Name(), `.log`) {
+			continue
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			continue
+		}
+
+		// Nothing in it was written after this time
+		if info.ModTime().Before(since) {
+			continue
+		}
+
+		files = append(files, logFile{filepath.Join(folder, d.Name()), info.ModTime()})
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].modTime.Before(files[j].modTime)
+	})
+
+	paths := make([]string, 0, len(files))
+	for _, f := range files {
+		paths = append(paths, f.path)
+	}
+
+	return paths, nil
+}
@@ -0,0 +1,36 @@
+package chatlog
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSearch(t *testing.T) {
+
+	if err := Open(t.TempDir(), 1); err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer Close()
+
+	Log(Entry{Kind: KindSay, RoomId: 1, FromUserId: 2, FromName: `Bobbin`, Text: `hello`})
+	Log(Entry{Kind: KindWhisper, RoomId: 1, FromUserId: 3, FromName: `Carlotta`, ToUserId: 2, ToName: `Bobbin`, Text: `psst`})
+	Log(Entry{Kind: KindShout, RoomId: 5, FromUserId: 3, FromName: `Carlotta`, Text: `HEY`})
+
+	found, err := Search(time.Now().Add(-time.Hour), func(e Entry) bool { return e.Involves(2) }, 10)
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+
+	if len(found) != 2 || found[0].Text != `hello` || found[1].Text != `psst` {
+		t.Errorf("Search() = %+v, want hello then psst", found)
+	}
+
+	found, _ = Search(time.Now().Add(-time.Hour), func(e Entry) bool { return e.RoomId == 5 }, 10)
+	if len(found) != 1 || found[0].Kind != KindShout {
+		t.Errorf("Search() by room = %+v, want the shout", found)
+	}
+
+	if found, _ = Search(time.Now().Add(time.Hour), func(e Entry) bool { return true }, 10); len(found) != 0 {
+		t.Errorf("Search() in the future = %+v, want nothing", found)
+	}
+}
@@ -0,0 +1,126 @@
+package chatlog
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/volte6/gomud/util"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	reportFolderName = `reports`
+)
+
+// A player's report of another player, with the chat leading up to it
+type Report struct {
+	ReportId       string    `yaml:"reportid"`
+	When           time.Time `yaml:"when"`
+	RoomId         int       `yaml:"roomid"` // Where the reporter was
+	ReporterUserId int       `yaml:"reporteruserid"`
+	ReporterName   string    `yaml:"reportername"`
+	ReportedUserId int       `yaml:"reporteduserid"`
+	ReportedName   string    `yaml:"reportedname"`
+	Reason         string    `yaml:"reason"`
+	Lines          []Entry   `yaml:"lines,omitempty"` // Recent chat involving either player, or in the reporters room
+}
+
+// Saves a report, snapshotting up to lineCount recent lines of chat around it
+func NewReport(roomId int, reporterUserId int, reporterName string, reportedUserId int, reportedName string, reason string, lineCount int) (*Report, error) {
+
+	now := time.Now()
+
+	r := &Report{
+		ReportId:       now.Format(`20060102-150405`) + fmt.Sprintf(`-%d`, reporterUserId),
+		When:           now,
+		RoomId:         roomId,
+		ReporterUserId: reporterUserId,
+		ReporterName:   reporterName,
+		ReportedUserId: reportedUserId,
+		ReportedName:   reportedName,
+		Reason:         reason,
+	}
+
+	r.Lines = Recent(func(e Entry) bool {
+		return e.RoomId == roomId || e.Involves(reporterUserId) || e.Involves(reportedUserId)
+	}, lineCount)
+
+	lock.Lock()
+	folder := logFolder
+	lock.Unlock()
+
+	if folder == `` {
+		return r, fmt.Errorf(`chat logging is disabled`)
+	}
+
+	bytes, err := yaml.Marshal(r)
+	if err != nil {
+		return r, err
+	}
+
+	return r, util.Save(filepath.Join(folder, reportFolderName, r.ReportId+`.yaml`), bytes)
+}
+
+// Returns a saved report
+func GetReport(reportId string) (*Report, error) {
+
+	lock.Lock()
+	folder := logFolder
+	lock.Unlock()
+
+	// Don't let the id wander outside the reports folder
+	if reportId == `` || strings.ContainsAny(reportId, `/\.`) {
+		return nil, fmt.Errorf(`invalid report id: %s`, reportId)
+	}
+
+	bytes, err := os.ReadFile(filepath.Join(folder, reportFolderName, reportId+`.yaml`))
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Report{}
+	if err := yaml.Unmarshal(bytes, r); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Returns up to limit of the most recent reports, newest first
+func GetReports(limit int) ([]*Report, error) {
+
+	lock.Lock()
+	folder := logFolder
+	lock.Unlock()
+
+	dirEntries, err := os.ReadDir(filepath.Join(folder, reportFolderName))
+	if err != nil {
+		return nil, err
+	}
+
+	reportIds := []string{}
+	for _, d := range dirEntries {
+		if !d.IsDir() && strings.HasSuffix(d.Name(), `.yaml`) {
+			reportIds = append(reportIds, strings.TrimSuffix(d.Name(), `.yaml`))
+		}
+	}
+
+	// Ids start with when they were made
+	sort.Sort(sort.Reverse(sort.StringSlice(reportIds)))
+
+	reports := []*Report{}
+	for _, reportId := range reportIds {
+		if len(reports) >= limit {
+			break
+		}
+		if r, err := GetReport(reportId); err == nil {
+			reports = append(reports, r)
+		}
+	}
+
+	return reports, nil
+}
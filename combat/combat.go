@@ -288,7 +288,13 @@ func calculateCombat(sourceChar characters.Character, targetChar characters.Char
 			// Get default racial dice rolls
 			attacks, dCount, dSides, dBonus, critBuffs := sourceChar.GetDefaultDiceRoll()
 
-			if weapon.ItemId > 0 {
+			// Worn down weapons don't hit as hard
+			weaponEffectiveness := 100
+
+			// Broken weapons are no better than fighting unarmed
+			if weapon.ItemId > 0 && !weapon.IsBroken() {
+
+				weaponEffectiveness = weapon.Effectiveness()
 
 				itemSpec := weapon.GetSpec()
 
@@ -321,6 +327,7 @@ func calculateCombat(sourceChar characters.Character, targetChar characters.Char
 				if Hits(sourceChar.Stats.Speed.ValueAdj, targetChar.Stats.Speed.ValueAdj, penalty) {
 					attackResult.Hit = true
 					attackTargetDamage = util.RollDice(dCount, dSides) + dBonus
+					attackTargetDamage = attackTargetDamage * weaponEffectiveness / 100
 
 					if attackResult.Crit || Crits(sourceChar, targetChar) {
 						attackResult.Crit = true
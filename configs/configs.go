@@ -23,10 +23,13 @@ type Config struct {
 	FolderItemData               ConfigString      `yaml:"FolderItemData"`
 	FolderAttackMessageData      ConfigString      `yaml:"FolderAttackMessageData"`
 	FolderUserData               ConfigString      `yaml:"FolderUserData"`
+	FolderChatLogs               ConfigString      `yaml:"FolderChatLogs"`  // Where player communications are logged. Empty disables chat logging
+	FolderBoardData              ConfigString      `yaml:"FolderBoardData"` // Where bulletin board posts are kept
 	FolderSpellData              ConfigString      `yaml:"FolderSpellData"`
 	FolderTemplates              ConfigString      `yaml:"FolderTemplates"`
 	FileAnsiAliases              ConfigString      `yaml:"FileAnsiAliases"`
 	FileKeywords                 ConfigString      `yaml:"FileKeywords"`
+	FileSocials                  ConfigString      `yaml:"FileSocials"`
 	AllowItemBuffRemoval         ConfigBool        `yaml:"AllowItemBuffRemoval"`
 	CarefulSaveFiles             ConfigBool        `yaml:"CarefulSaveFiles"`
 	AuctionsEnabled              ConfigBool        `yaml:"AuctionsEnabled"`
@@ -81,6 +84,48 @@ type Config struct {
 	LivesOnLevelUp ConfigInt  `yaml:"LivesOnLevelUp"` // # lives gained on level up
 	PricePerLife   ConfigInt  `yaml:"PricePerLife"`   // Price in gold to buy new lives
 
+	// Player housing related configs
+	HousingRent            ConfigInt    `yaml:"HousingRent"`            // Gold collected from the owners bank each rent period
+	HousingRentPeriod      ConfigString `yaml:"HousingRentPeriod"`      // How often rent is collected
+	HousingMissedRentLimit ConfigInt    `yaml:"HousingMissedRentLimit"` // How many missed payments in a row before eviction
+
+	// Mount related configs
+	StableFee     ConfigInt `yaml:"StableFee"`     // Gold charged to leave a pet at a stable
+	StableMaxPets ConfigInt `yaml:"StableMaxPets"` // How many pets a character can have stabled at once
+
+	// Mail related configs
+	MailPostage     ConfigInt `yaml:"MailPostage"`     // Gold charged to send a letter from a post office
+	MailSendLimit   ConfigInt `yaml:"MailSendLimit"`   // How many letters a player can send per real hour
+	MailInboxSize   ConfigInt `yaml:"MailInboxSize"`   // How many letters an inbox holds before players can't send more to it
+	MailArchiveSize ConfigInt `yaml:"MailArchiveSize"` // How many letters a player can keep archived
+
+	// Bulletin board related configs
+	BoardMaxPosts      ConfigInt `yaml:"BoardMaxPosts"`      // How many posts a bulletin board holds before players can't post more to it
+	BoardMaxPostLength ConfigInt `yaml:"BoardMaxPostLength"` // How many characters a post can be
+
+	// Tell related configs
+	TellQueueSize ConfigInt `yaml:"TellQueueSize"` // How many tells can wait for a player who is offline
+
+	// Chat log related configs
+	ChatLogMaxAgeDays ConfigInt `yaml:"ChatLogMaxAgeDays"` // How many days rotated chat logs are kept
+	ChatReportLines   ConfigInt `yaml:"ChatReportLines"`   // How many recent lines of chat are saved with a player report
+
+	// Chat filter related configs
+	ChatFilterEnabled     ConfigBool `yaml:"ChatFilterEnabled"`     // Whether player text is run through the word lists and spam checks
+	SpamMessagesPerMinute ConfigInt  `yaml:"SpamMessagesPerMinute"` // Most messages a player can send in a minute
+	SpamRepeatLimit       ConfigInt  `yaml:"SpamRepeatLimit"`       // How many times in a row a player can send the same message
+	SpamCapsPercent       ConfigInt  `yaml:"SpamCapsPercent"`       // Messages with at least this percent of capital letters are lowered
+	SpamStrikesToMute     ConfigInt  `yaml:"SpamStrikesToMute"`     // How many blocked messages in 10 minutes get a player muted
+	SpamMuteMinutes       ConfigInt  `yaml:"SpamMuteMinutes"`       // How long the first auto mute lasts. Each one after lasts twice as long
+
+	// Quest related configs
+	QuestBoardOffers ConfigInt `yaml:"QuestBoardOffers"` // How many quests a quest board offers each day
+
+	// Discord related configs
+	DiscordWebhookUrl  ConfigString      `yaml:"DiscordWebhookUrl"`  // Webhook that mirrored messages are posted to. Empty disables mirroring
+	DiscordMirror      ConfigSliceString `yaml:"DiscordMirror"`      // Channel ids and events (auctions, levelups, deaths, achievements) to mirror
+	DiscordRelaySecret ConfigString      `yaml:"DiscordRelaySecret"` // Secret a local relay must send to post messages into channels. Empty disables the relay
+
 	ShopRestockRate          ConfigString `yaml:"ShopRestockRate"`          // Default time it takes to restock 1 quantity in shops
 	ConsistentAttackMessages ConfigBool   `yaml:"ConsistentAttackMessages"` // Whether each weapon has consistent attack messages
 	MaxAltCharacters         ConfigInt    `yaml:"MaxAltCharacters"`         // How many characters beyond the default character can they create?
@@ -320,8 +365,22 @@ func (c *Config) SetOverrides(overrides map[string]any) error {
 		if !method.IsValid() {
 			return fmt.Errorf("Set method missing")
 		}
+		strValue := fmt.Sprintf(`%v`, value)
+
+		// Lists are set from values separated by semicolons
+		switch list := value.(type) {
+		case ConfigSliceString:
+			strValue = strings.Join(list, `;`)
+		case []any:
+			parts := make([]string, 0, len(list))
+			for _, part := range list {
+				parts = append(parts, fmt.Sprintf(`%v`, part))
+			}
+			strValue = strings.Join(parts, `;`)
+		}
+
 		// Prepare arguments and call the method as before
-		args := []reflect.Value{reflect.ValueOf(fmt.Sprintf(`%v`, value))}
+		args := []reflect.Value{reflect.ValueOf(strValue)}
 		method.Call(args)
 
 	}
@@ -348,6 +407,10 @@ func (c *Config) Validate() {
 		c.FolderUserData = `_datafiles/users` // default
 	}
 
+	if c.FolderBoardData == `` {
+		c.FolderBoardData = `_datafiles/boards` // default
+	}
+
 	if c.FolderSpellData == `` {
 		c.FolderSpellData = `_datafiles/spells` // default
 	}
@@ -364,6 +427,10 @@ func (c *Config) Validate() {
 		c.FileKeywords = `_datafiles/keywords.yaml` // default
 	}
 
+	if c.FileSocials == `` {
+		c.FileSocials = `_datafiles/socials.yaml` // default
+	}
+
 	if c.TimeFormat == `` {
 		c.TimeFormat = `Monday, 02-Jan-2006 03:04:05PM`
 	}
@@ -499,6 +566,86 @@ func (c *Config) Validate() {
 
 	// Nothing to do with Locked
 
+	if c.HousingRent < 0 {
+		c.HousingRent = 0 // default
+	}
+
+	if c.HousingRentPeriod == `` {
+		c.HousingRentPeriod = `1 week` // default
+	}
+
+	if c.HousingMissedRentLimit < 1 {
+		c.HousingMissedRentLimit = 3 // default
+	}
+
+	if c.StableFee < 0 {
+		c.StableFee = 0 // default
+	}
+
+	if c.StableMaxPets < 1 {
+		c.StableMaxPets = 3 // default
+	}
+
+	if c.MailPostage < 0 {
+		c.MailPostage = 0 // default
+	}
+
+	if c.MailSendLimit < 1 {
+		c.MailSendLimit = 10 // default
+	}
+
+	if c.MailInboxSize < 1 {
+		c.MailInboxSize = 30 // default
+	}
+
+	if c.MailArchiveSize < 0 {
+		c.MailArchiveSize = 0 // default
+	}
+
+	if c.BoardMaxPosts < 1 {
+		c.BoardMaxPosts = 100 // default
+	}
+
+	if c.BoardMaxPostLength < 1 {
+		c.BoardMaxPostLength = 2048 // default
+	}
+
+	if c.TellQueueSize < 1 {
+		c.TellQueueSize = 10 // default
+	}
+
+	if c.ChatLogMaxAgeDays < 1 {
+		c.ChatLogMaxAgeDays = 30 // default
+	}
+
+	if c.ChatReportLines < 1 {
+		c.ChatReportLines = 20 // default
+	}
+
+	if c.SpamMessagesPerMinute < 1 {
+		c.SpamMessagesPerMinute = 20 // default
+	}
+
+	if c.SpamRepeatLimit < 1 {
+		c.SpamRepeatLimit = 3 // default
+	}
+
+	if c.SpamCapsPercent < 1 || c.SpamCapsPercent > 100 {
+		c.SpamCapsPercent = 70 // default
+	}
+
+	if c.SpamStrikesToMute < 1 {
+		c.SpamStrikesToMute = 5 // default
+	}
+
+	if c.SpamMuteMinutes < 1 {
+		c.SpamMuteMinutes = 5 // default
+	}
+
+	if c.QuestBoardOffers < 1 {
+		c.QuestBoardOffers = 3 // default
+	}
+
 	// Pre-calculate and cache useful values
 	c.turnsPerRound = int((c.RoundSeconds * 1000) / c.TurnMs)
 	c.turnsPerSave = int(c.RoundsPerAutoSave) * c.turnsPerRound
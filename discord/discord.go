@@ -0,0 +1,233 @@
+package discord
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Volte6/ansitags"
+	"github.com/volte6/gomud/configs"
+	"github.com/volte6/gomud/events"
+)
+
+// Events that can be mirrored, besides chat channels which use their channel id
+const (
+	MirrorAuctions     = `auctions`
+	MirrorLevelUps     = `levelups`
+	MirrorDeaths       = `deaths`
+	MirrorAchievements = `achievements`
+
+	RelayPath         = `/discord/relay`
+	RelaySecretHeader = `X-Relay-Secret`
+
+	outboundQueueSize = 100
+	maxRelayBodySize  = 4096
+	maxRelayNameSize  = 32
+	maxRelayTextSize  = 400
+)
+
+var (
+	lock      sync.Mutex
+	transport Transport
+	outbound  chan Message
+)
+
+// A message posted to discord
+type Message struct {
+	Username string `json:"username,omitempty"` // Who the message appears to be from
+	Content  string `json:"content"`
+}
+
+// Sends messages somewhere outside the game.
+// Swap it out with SetTransport() to test against something other than discord.
+type Transport interface {
+	Send(msg Message) error
+}
+
+// Posts messages to a discord webhook url
+type WebhookTransport struct {
+	Url    string
+	Client *http.Client
+}
+
+func NewWebhookTransport(url string) *WebhookTransport {
+	return &WebhookTransport{
+		Url:    url,
+		Client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (t *WebhookTransport) Send(msg Message) error {
+
+	body, err := json.Marshal(struct {
+		Message
+		AllowedMentions map[string][]string `json:"allowed_mentions"` // Don't let players ping @everyone
+	}{msg, map[string][]string{`parse`: {}}})
+	if err != nil {
+		return err
+	}
+
+	resp, err := t.Client.Post(t.Url, `application/json`, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf(`webhook returned %s`, resp.Status)
+	}
+
+	return nil
+}
+
+// Sets where mirrored messages are sent, and starts sending them.
+// Passing nil stops mirroring.
+func SetTransport(t Transport) {
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	if outbound != nil {
+		close(outbound)
+		outbound = nil
+	}
+
+	transport = t
+	if t == nil {
+		return
+	}
+
+	outbound = make(chan Message, outboundQueueSize)
+
+	// Sending happens in the background so a slow webhook never holds up the game
+	go func(t Transport, queue chan Message) {
+		for msg := range queue {
+			if err := t.Send(msg); err != nil {
+				slog.Error("discord.Send()", "error", err)
+			}
+		}
+	}(t, outbound)
+}
+
+// Whether a channel id or event is configured to be mirrored
+func IsMirrored(kind string) bool {
+	for _, m := range configs.GetConfig().DiscordMirror {
+		if strings.EqualFold(m, kind) {
+			return true
+		}
+	}
+	return false
+}
+
+// Mirrors a message if its channel id or event is configured to be mirrored.
+// username may be empty, in which case the webhook's own name is used.
+func Mirror(kind string, username string, text string) {
+
+	if !IsMirrored(kind) {
+		return
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+
+	if outbound == nil {
+		return
+	}
+
+	msg := Message{
+		Username: ansitags.Parse(username, ansitags.StripTags),
+		Content:  strings.TrimSpace(ansitags.Parse(text, ansitags.StripTags)),
+	}
+
+	select {
+	case outbound <- msg:
+	default:
+		slog.Warn("discord.Mirror()", "error", "outbound queue full, message dropped", "kind", kind)
+	}
+}
+
+// A message posted to the relay endpoint
+type RelayMessage struct {
+	Channel string `json:"channel"` // Chat channel id to post to
+	Name    string `json:"name"`    // Discord name of whoever sent it
+	Text    string `json:"text"`
+}
+
+// Accepts messages from a relay running on the same machine, and posts them into chat channels.
+func HandleRelay(w http.ResponseWriter, r *http.Request) {
+
+	secret := string(configs.GetConfig().DiscordRelaySecret)
+	if secret == `` {
+		http.NotFound(w, r)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		http.Error(w, `method not allowed`, http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !isLocal(r.RemoteAddr) {
+		http.Error(w, `forbidden`, http.StatusForbidden)
+		return
+	}
+
+	if subtle.ConstantTimeCompare([]byte(r.Header.Get(RelaySecretHeader)), []byte(secret)) != 1 {
+		http.Error(w, `forbidden`, http.StatusForbidden)
+		return
+	}
+
+	msg := RelayMessage{}
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxRelayBodySize)).Decode(&msg); err != nil {
+		http.Error(w, `invalid message`, http.StatusBadRequest)
+		return
+	}
+
+	msg.Channel = strings.ToLower(strings.TrimSpace(msg.Channel))
+	msg.Name = truncate(strings.TrimSpace(ansitags.Parse(msg.Name, ansitags.StripTags)), maxRelayNameSize)
+	msg.Text = truncate(strings.TrimSpace(ansitags.Parse(msg.Text, ansitags.StripTags)), maxRelayTextSize)
+
+	if msg.Name == `` || msg.Text == `` {
+		http.Error(w, `name and text are required`, http.StatusBadRequest)
+		return
+	}
+
+	// Only channels that are mirrored out can be posted to
+	if !IsMirrored(msg.Channel) {
+		http.Error(w, `channel not relayed`, http.StatusForbidden)
+		return
+	}
+
+	events.AddToQueue(events.ChannelRelay{
+		ChannelId: msg.Channel,
+		Name:      msg.Name + `@discord`,
+		Text:      msg.Text,
+	})
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func isLocal(remoteAddr string) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return false
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func truncate(s string, maxRunes int) string {
+	r := []rune(s)
+	if len(r) > maxRunes {
+		return string(r[:maxRunes])
+	}
+	return s
+}
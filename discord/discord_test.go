@@ -0,0 +1,133 @@
+package discord
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/volte6/gomud/configs"
+	"github.com/volte6/gomud/events"
+)
+
+// Stands in for discord, passing along everything posted to it
+func newStandIn(t *testing.T, status int) (*httptest.Server, chan map[string]any) {
+
+	received := make(chan map[string]any, 10)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := map[string]any{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("stand-in: could not decode body: %s", err)
+		}
+		received <- body
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, received
+}
+
+func setConfig(t *testing.T, name string, value string) {
+	t.Setenv(`CONFIG_PATH`, filepath.Join(t.TempDir(), `config-overrides.yaml`))
+	if err := configs.SetVal(name, value); err != nil {
+		t.Fatalf("SetVal(%s): %s", name, err)
+	}
+}
+
+func TestWebhookTransport(t *testing.T) {
+
+	server, received := newStandIn(t, http.StatusNoContent)
+
+	if err := NewWebhookTransport(server.URL).Send(Message{Username: `Bob`, Content: `hi @everyone`}); err != nil {
+		t.Fatalf("Send(): unexpected error: %s", err)
+	}
+
+	body := <-received
+	if body[`username`] != `Bob` || body[`content`] != `hi @everyone` {
+		t.Errorf("Send(): unexpected body %v", body)
+	}
+	if _, ok := body[`allowed_mentions`]; !ok {
+		t.Errorf("Send(): expected mentions to be disabled, got %v", body)
+	}
+
+	failing, _ := newStandIn(t, http.StatusBadRequest)
+	if err := NewWebhookTransport(failing.URL).Send(Message{Content: `hi`}); err == nil {
+		t.Errorf("Send(): expected an error when the webhook fails")
+	}
+}
+
+func TestMirror(t *testing.T) {
+
+	setConfig(t, `DiscordMirror`, `broadcast;deaths`)
+
+	server, received := newStandIn(t, http.StatusNoContent)
+
+	SetTransport(NewWebhookTransport(server.URL))
+	t.Cleanup(func() { SetTransport(nil) })
+
+	Mirror(`trade`, `Bob`, `not mirrored`)
+	Mirror(MirrorDeaths, ``, `<ansi fg="username">Bob</ansi> has died!`)
+
+	select {
+	case body := <-received:
+		if body[`content`] != `Bob has died!` {
+			t.Errorf("Mirror(): expected tags to be stripped, got %v", body[`content`])
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Mirror(): nothing was sent")
+	}
+
+	select {
+	case body := <-received:
+		t.Errorf("Mirror(): unexpected message %v", body)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestHandleRelay(t *testing.T) {
+
+	setConfig(t, `DiscordMirror`, `broadcast;deaths`)
+	setConfig(t, `DiscordRelaySecret`, `letmein`)
+
+	tests := []struct {
+		remoteAddr string
+		secret     string
+		body       string
+		expected   int
+	}{
+		{`127.0.0.1:5000`, `letmein`, `{"channel":"broadcast","name":"Bob","text":"hello"}`, http.StatusAccepted},
+		{`127.0.0.1:5000`, `wrong`, `{"channel":"broadcast","name":"Bob","text":"hello"}`, http.StatusForbidden},
+		{`192.0.2.1:5000`, `letmein`, `{"channel":"broadcast","name":"Bob","text":"hello"}`, http.StatusForbidden},
+		{`127.0.0.1:5000`, `letmein`, `{"channel":"trade","name":"Bob","text":"hello"}`, http.StatusForbidden},
+		{`127.0.0.1:5000`, `letmein`, `{"channel":"broadcast","name":"Bob","text":""}`, http.StatusBadRequest},
+		{`127.0.0.1:5000`, `letmein`, `not json`, http.StatusBadRequest},
+	}
+
+	for _, test := range tests {
+
+		req := httptest.NewRequest(http.MethodPost, RelayPath, strings.NewReader(test.body))
+		req.RemoteAddr = test.remoteAddr
+		req.Header.Set(RelaySecretHeader, test.secret)
+
+		rec := httptest.NewRecorder()
+		HandleRelay(rec, req)
+
+		if rec.Code != test.expected {
+			t.Errorf("HandleRelay(%s, %s, %s): expected %d, got %d", test.remoteAddr, test.secret, test.body, test.expected, rec.Code)
+		}
+	}
+
+	eq := events.GetQueue(events.ChannelRelay{})
+	if eq.Len() != 1 {
+		t.Fatalf("HandleRelay(): expected 1 relayed message, got %d", eq.Len())
+	}
+
+	relay := eq.Poll().(events.ChannelRelay)
+	if relay.ChannelId != `broadcast` || relay.Name != `Bob@discord` || relay.Text != `hello` {
+		t.Errorf("HandleRelay(): unexpected relayed message %+v", relay)
+	}
+}
@@ -0,0 +1,357 @@
+package editor
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/volte6/gomud/connections"
+	"github.com/volte6/gomud/events"
+	"github.com/volte6/gomud/term"
+	"github.com/volte6/gomud/util"
+)
+
+const (
+	InputHandlerName = `EditorInputHandler`
+
+	finishCommand = `.`
+	lineWidth     = 72 // Existing text is broken into lines this long so it can be edited line by line
+	previewWidth  = 80
+)
+
+// A line editor for writing more than fits on a single line, such as descriptions, letters and posts.
+// While it's open it takes over the players input, one line at a time, until they finish or abort.
+// Letters and posts keep their line breaks, and blank lines between paragraphs.
+// Descriptions join their lines with spaces, since everything that shows them wraps them anyway.
+type Editor struct {
+	lock      sync.Mutex
+	userId    int
+	title     string
+	lines     []string
+	joinLines bool
+	finished  bool
+	aborted   bool
+}
+
+type editorCommand struct {
+	aliases     []string
+	usage       string
+	description string
+	run         func(e *Editor, arg string) string
+}
+
+var (
+	commands []editorCommand
+)
+
+func init() {
+	commands = []editorCommand{
+		{[]string{`/help`, `/h`, `/?`}, `/help`, `Show this list`, (*Editor).help},
+		{[]string{`/list`, `/l`}, `/list`, `Show every line, numbered`, (*Editor).list},
+		{[]string{`/preview`, `/p`}, `/preview`, `Show the text as others will see it`, (*Editor).preview},
+		{[]string{`/insert`, `/i`}, `/insert [#] [text]`, `Add a line before line #`, (*Editor).insert},
+		{[]string{`/replace`, `/r`}, `/replace [#] [text]`, `Replace line #`, (*Editor).replace},
+		{[]string{`/delete`, `/d`}, `/delete [#]`, `Remove line #, or a range such as 2-4`, (*Editor).delete},
+		{[]string{`/clear`}, `/clear`, `Remove every line`, (*Editor).clear},
+		{[]string{`/abort`}, `/abort`, `Stop without saving any changes`, (*Editor).abort},
+	}
+}
+
+// Starts an editor for userId, holding text to begin with.
+// If joinLines is set, the finished text is a single paragraph.
+func New(userId int, title string, text string, joinLines bool) *Editor {
+
+	e := &Editor{
+		userId:    userId,
+		title:     title,
+		lines:     []string{},
+		joinLines: joinLines,
+	}
+
+	if text = strings.TrimSpace(text); text == `` {
+		return e
+	}
+
+	if !joinLines {
+		for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+			e.lines = append(e.lines, strings.TrimSpace(line))
+		}
+		return e
+	}
+
+	wrapped := util.SplitStringNL(text, lineWidth)
+	for _, line := range strings.Split(wrapped, term.CRLFStr) {
+		if line = strings.TrimSpace(line); line != `` {
+			e.lines = append(e.lines, line)
+		}
+	}
+
+	return e
+}
+
+// Tells the player how to use the editor, and shows anything already written
+func (e *Editor) Start() {
+
+	out := strings.Builder{}
+
+	out.WriteString(fmt.Sprintf(`<ansi fg="yellow-bold">%s</ansi>`, e.title) + term.CRLFStr)
+	out.WriteString(fmt.Sprintf(`Type one line at a time. Type <ansi fg="command">%s</ansi> on a line by itself when you're done, <ansi fg="command">/abort</ansi> to stop, or <ansi fg="command">/help</ansi> for more.`, finishCommand) + term.CRLFStr)
+
+	e.lock.Lock()
+	if len(e.lines) > 0 {
+		out.WriteString(term.CRLFStr)
+		out.WriteString(e.list(``))
+	}
+	e.lock.Unlock()
+
+	e.send(out.String())
+}
+
+// Returns the finished text. The text is empty if they aborted or wrote nothing.
+// Returns false if they're still writing.
+func (e *Editor) Text() (string, bool) {
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if !e.finished {
+		return ``, false
+	}
+
+	if e.aborted {
+		return ``, true
+	}
+
+	return e.text(), true
+}
+
+// Takes each line the player enters, until they finish.
+// Goes on the players connection with connections.ConnectionDetails.AddInputHandler()
+func (e *Editor) InputHandler(clientInput *connections.ClientInput, sharedState map[string]any) (nextHandler bool) {
+
+	// if they didn't hit enter, just keep buffering, go next.
+	if !clientInput.EnterPressed {
+		return true
+	}
+
+	line := strings.TrimSpace(string(clientInput.Buffer))
+
+	e.lock.Lock()
+
+	// Finished, but not taken off the connection yet
+	if e.finished {
+		e.lock.Unlock()
+		return true
+	}
+
+	output := e.handleLine(line)
+	finished := e.finished
+
+	e.lock.Unlock()
+
+	// zero out the current buffer
+	clientInput.Buffer = clientInput.Buffer[:0]
+
+	e.send(output)
+
+	// Let the game know, so whatever asked for the text can pick it up
+	if finished {
+		events.AddToQueue(events.Input{
+			UserId:    e.userId,
+			InputText: ``,
+			WaitTurns: -1,
+		})
+	}
+
+	return false
+}
+
+// Must be called with the lock held
+func (e *Editor) handleLine(line string) string {
+
+	if line == finishCommand {
+		e.finished = true
+		return ``
+	}
+
+	// A blank line separates paragraphs, unless it's all going to be one paragraph
+	if line == `` {
+		if !e.joinLines && len(e.lines) > 0 {
+			e.lines = append(e.lines, ``)
+		}
+		return ``
+	}
+
+	if line[0:1] != `/` {
+		e.lines = append(e.lines, line)
+		return ``
+	}
+
+	cmd, arg, _ := strings.Cut(line, ` `)
+	cmd = strings.ToLower(cmd)
+
+	for _, c := range commands {
+		for _, alias := range c.aliases {
+			if alias == cmd {
+				return c.run(e, strings.TrimSpace(arg))
+			}
+		}
+	}
+
+	return fmt.Sprintf(`<ansi fg="red">%s</ansi> isn't an editor command. Type <ansi fg="command">/help</ansi> to see what you can do.`, cmd)
+}
+
+func (e *Editor) help(arg string) string {
+
+	out := strings.Builder{}
+	out.WriteString(`<ansi fg="yellow-bold">Editor commands:</ansi>` + term.CRLFStr)
+	out.WriteString(fmt.Sprintf(`  <ansi fg="command">%-20s</ansi> %s`, finishCommand, `Finish and save what you've written`) + term.CRLFStr)
+	for _, c := range commands {
+		out.WriteString(fmt.Sprintf(`  <ansi fg="command">%-20s</ansi> %s`, c.usage, c.description) + term.CRLFStr)
+	}
+	out.WriteString(`Anything else is added as a new line at the end.`)
+
+	return out.String()
+}
+
+func (e *Editor) list(arg string) string {
+
+	if len(e.lines) == 0 {
+		return `Nothing has been written yet.`
+	}
+
+	out := strings.Builder{}
+	for i, line := range e.lines {
+		if i > 0 {
+			out.WriteString(term.CRLFStr)
+		}
+		out.WriteString(fmt.Sprintf(`<ansi fg="black-bold">%3d:</ansi> %s`, i+1, line))
+	}
+
+	return out.String()
+}
+
+func (e *Editor) preview(arg string) string {
+
+	if len(e.lines) == 0 {
+		return `Nothing has been written yet.`
+	}
+
+	if e.joinLines {
+		return util.SplitStringNL(e.text(), previewWidth)
+	}
+
+	return util.SplitLinesNL(e.text(), previewWidth)
+}
+
+func (e *Editor) insert(arg string) string {
+
+	lineNum, text, err := e.lineArg(arg, true)
+	if err != `` {
+		return err
+	}
+
+	if text == `` {
+		return `Insert what? Type <ansi fg="command">/insert [#] [text]</ansi>.`
+	}
+
+	idx := lineNum - 1
+	e.lines = append(e.lines[:idx], append([]string{text}, e.lines[idx:]...)...)
+
+	return fmt.Sprintf(`Added line %d.`, lineNum)
+}
+
+func (e *Editor) replace(arg string) string {
+
+	lineNum, text, err := e.lineArg(arg, false)
+	if err != `` {
+		return err
+	}
+
+	if text == `` {
+		return `Replace it with what? Type <ansi fg="command">/replace [#] [text]</ansi>.`
+	}
+
+	e.lines[lineNum-1] = text
+
+	return fmt.Sprintf(`Replaced line %d.`, lineNum)
+}
+
+func (e *Editor) delete(arg string) string {
+
+	if len(e.lines) == 0 {
+		return `There are no lines yet.`
+	}
+
+	from, to, _ := strings.Cut(arg, `-`)
+	if to == `` {
+		to = from
+	}
+
+	fromNum, err1 := strconv.Atoi(strings.TrimSpace(from))
+	toNum, err2 := strconv.Atoi(strings.TrimSpace(to))
+
+	if err1 != nil || err2 != nil || fromNum < 1 || toNum < fromNum || toNum > len(e.lines) {
+		return fmt.Sprintf(`Delete which line? Type <ansi fg="command">/delete [#]</ansi> with a number from 1 to %d.`, len(e.lines))
+	}
+
+	e.lines = append(e.lines[:fromNum-1], e.lines[toNum:]...)
+
+	if fromNum == toNum {
+		return fmt.Sprintf(`Deleted line %d.`, fromNum)
+	}
+
+	return fmt.Sprintf(`Deleted lines %d to %d.`, fromNum, toNum)
+}
+
+func (e *Editor) clear(arg string) string {
+	e.lines = e.lines[:0]
+	return `Everything has been cleared.`
+}
+
+func (e *Editor) abort(arg string) string {
+	e.finished = true
+	e.aborted = true
+	return `You stop editing.`
+}
+
+// Splits "[#] [text]" into the line number and the text.
+// When inserting, the number can be one past the last line to add to the end.
+func (e *Editor) lineArg(arg string, inserting bool) (int, string, string) {
+
+	numStr, text, _ := strings.Cut(arg, ` `)
+
+	maxLine := len(e.lines)
+	if inserting {
+		maxLine++
+	}
+
+	lineNum, err := strconv.Atoi(numStr)
+	if err != nil || lineNum < 1 || lineNum > maxLine {
+		if maxLine == 0 {
+			return 0, ``, `There are no lines yet.`
+		}
+		return 0, ``, fmt.Sprintf(`Which line? Use a number from 1 to %d.`, maxLine)
+	}
+
+	return lineNum, strings.TrimSpace(text), ``
+}
+
+func (e *Editor) text() string {
+	if e.joinLines {
+		return strings.Join(e.lines, ` `)
+	}
+	return strings.TrimSpace(strings.Join(e.lines, "\n"))
+}
+
+// Sends output to the player. The line prompt is redrawn after it.
+func (e *Editor) send(txt string) {
+	if txt != `` {
+		txt += "\n"
+	}
+	events.AddToQueue(events.Message{
+		UserId: e.userId,
+		Text:   txt,
+	})
+}
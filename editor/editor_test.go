@@ -0,0 +1,83 @@
+package editor
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEditorLines(t *testing.T) {
+
+	e := New(1, `Test`, `The quick brown fox`, true)
+
+	for _, line := range []string{
+		`jumps over`,
+		`the dog.`,
+		`/insert 3 the lazy`,
+		`/replace 1 A quick brown fox`,
+		`/delete 9`,
+		`/nope`,
+	} {
+		e.handleLine(line)
+	}
+
+	if got, want := strings.Join(e.lines, `|`), `A quick brown fox|jumps over|the lazy|the dog.`; got != want {
+		t.Errorf("lines = %q, want %q", got, want)
+	}
+
+	if _, finished := e.Text(); finished {
+		t.Errorf("Text() finished before . was entered")
+	}
+
+	e.handleLine(`/delete 2-3`)
+	e.handleLine(`.`)
+
+	if text, finished := e.Text(); !finished || text != `A quick brown fox the dog.` {
+		t.Errorf("Text() = %q, %v, want %q, true", text, finished, `A quick brown fox the dog.`)
+	}
+}
+
+func TestEditorKeepsLineBreaks(t *testing.T) {
+
+	e := New(1, `Test`, "Dear Bob,\n\nHello.", false)
+
+	for _, line := range []string{
+		`How are you?`,
+		``,
+		`Alice`,
+		``,
+		`.`,
+	} {
+		e.handleLine(line)
+	}
+
+	want := "Dear Bob,\n\nHello.\nHow are you?\n\nAlice"
+	if text, finished := e.Text(); !finished || text != want {
+		t.Errorf("Text() = %q, %v, want %q, true", text, finished, want)
+	}
+}
+
+func TestEditorAbort(t *testing.T) {
+
+	e := New(1, `Test`, `Something to keep`, true)
+	e.handleLine(`more`)
+	e.handleLine(`/abort`)
+
+	if text, finished := e.Text(); !finished || text != `` {
+		t.Errorf("Text() after /abort = %q, %v, want empty, true", text, finished)
+	}
+}
+
+func TestEditorWrapsExistingText(t *testing.T) {
+
+	e := New(1, `Test`, strings.Repeat(`word `, 40), true)
+
+	if len(e.lines) < 2 {
+		t.Fatalf("long text was not broken into lines: %d", len(e.lines))
+	}
+
+	for i, line := range e.lines {
+		if len(line) > lineWidth {
+			t.Errorf("line %d is %d long, want at most %d", i+1, len(line), lineWidth)
+		}
+	}
+}
@@ -17,6 +17,26 @@ type Quest struct {
 
 func (q Quest) Type() string { return `Quest` }
 
+// Used for tracking progress toward quest objectives, such as killing a mob or visiting a room
+type QuestObjective struct {
+	UserId        int
+	ObjectiveType string
+	MobId         int
+	ItemId        int
+	RoomId        int
+	Shared        bool // Passed along from a party member, so it isn't passed along again
+}
+
+func (q QuestObjective) Type() string { return `QuestObjective` }
+
+// Something happened that might unlock achievements, such as a kill or a death
+type Achievement struct {
+	UserId       int
+	CriteriaType string // Only achievements with this type of criteria are checked. Empty checks all of them
+}
+
+func (a Achievement) Type() string { return `Achievement` }
+
 // For special room-targetting actions
 type RoomAction struct {
 	RoomId       int
@@ -48,6 +68,7 @@ func (b Broadcast) Type() string { return `Broadcast` }
 
 type Message struct {
 	UserId          int
+	FromUserId      int // Who sent a communication. Players ignoring them won't receive it
 	ExcludeUserIds  []int
 	RoomId          int
 	Text            string
@@ -74,11 +95,21 @@ type GMCPIn struct {
 
 func (b GMCPIn) Type() string { return `GMCP` }
 
-// Messages that are intended to reach all users on the system
+// GMCP data sent to a users client
 type GMCPOut struct {
 	ConnectionId uint64
 	UserId       int
+	Module       string // Such as "Comm.Channel.Text"
 	Payload      any
 }
 
 func (b GMCPOut) Type() string { return `GMCP` }
+
+// Chat channel messages that arrive from outside the game, such as a discord relay
+type ChannelRelay struct {
+	ChannelId string
+	Name      string
+	Text      string
+}
+
+func (c ChannelRelay) Type() string { return `ChannelRelay` }
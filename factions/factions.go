@@ -0,0 +1,123 @@
+package factions
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/volte6/gomud/fileloader"
+)
+
+const (
+	ReputationMin = -1000
+	ReputationMax = 1000
+
+	defaultKillPenalty = 10
+)
+
+var (
+	allFactions          = map[string]*Faction{}
+	factionDataFilesPath = "_datafiles/factions"
+)
+
+type Faction struct {
+	FactionId   string   `yaml:"factionid"`             // Unique id of the faction ("frostfang")
+	Name        string   `yaml:"name"`                  // Display name of the faction
+	Description string   `yaml:"description,omitempty"` // Shown in the reputation list
+	Groups      []string `yaml:"groups,omitempty"`      // Mob groups that belong to this faction
+	Enemies     []string `yaml:"enemies,omitempty"`     // Faction id's that think better of you when you kill members of this faction
+	KillPenalty int      `yaml:"killpenalty,omitempty"` // Reputation lost for killing a member. Enemies gain half of this. Defaults to 10
+	Secret      bool     `yaml:"secret,omitempty"`      // Secret factions aren't listed until the player has some standing with them
+}
+
+func (f *Faction) Id() string {
+	return f.FactionId
+}
+
+func (f *Faction) Filepath() string {
+	return fmt.Sprintf("%s.yaml", f.FactionId)
+}
+
+func (f *Faction) Validate() error {
+
+	f.FactionId = strings.ToLower(f.FactionId)
+
+	if f.Name == `` {
+		f.Name = f.FactionId
+	}
+
+	if f.KillPenalty == 0 {
+		f.KillPenalty = defaultKillPenalty // default
+	}
+
+	for i := range f.Groups {
+		f.Groups[i] = strings.ToLower(f.Groups[i])
+	}
+
+	return nil
+}
+
+// Whether a mob group belongs to this faction
+func (f *Faction) HasGroup(groupName string) bool {
+	groupName = strings.ToLower(groupName)
+	for _, g := range f.Groups {
+		if g == groupName {
+			return true
+		}
+	}
+	return false
+}
+
+func GetFaction(factionId string) *Faction {
+	if f, ok := allFactions[strings.ToLower(factionId)]; ok {
+		return f
+	}
+	return nil
+}
+
+// Returns all faction id's in a consistent order
+func GetFactionIds() []string {
+	ids := []string{}
+	for id := range allFactions {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Returns the factions any of the mob groups provided belong to
+func GetFactionsForGroups(groups []string) []*Faction {
+
+	found := []*Faction{}
+
+	if len(groups) == 0 {
+		return found
+	}
+
+	for _, factionId := range GetFactionIds() {
+		f := allFactions[factionId]
+		for _, groupName := range groups {
+			if f.HasGroup(groupName) {
+				found = append(found, f)
+				break
+			}
+		}
+	}
+
+	return found
+}
+
+func LoadDataFiles() {
+
+	start := time.Now()
+
+	var err error
+	allFactions, err = fileloader.LoadAllFlatFiles[string, *Faction](factionDataFilesPath)
+	if err != nil {
+		panic(err)
+	}
+
+	slog.Info("factions.LoadDataFiles()", "loadedCount", len(allFactions), "Time Taken", time.Since(start))
+}
@@ -0,0 +1,88 @@
+package factions
+
+// How a faction regards a character, based on their reputation with it
+type Standing int
+
+const (
+	StandingHated Standing = iota
+	StandingUnfriendly
+	StandingNeutral
+	StandingFriendly
+	StandingHonored
+)
+
+var (
+	standingNames = map[Standing]string{
+		StandingHated:      `Hated`,
+		StandingUnfriendly: `Unfriendly`,
+		StandingNeutral:    `Neutral`,
+		StandingFriendly:   `Friendly`,
+		StandingHonored:    `Honored`,
+	}
+
+	standingColors = map[Standing]string{
+		StandingHated:      `red-bold`,
+		StandingUnfriendly: `red`,
+		StandingNeutral:    `white`,
+		StandingFriendly:   `green`,
+		StandingHonored:    `green-bold`,
+	}
+
+	// Percent added to (or taken off of) shop prices
+	standingPriceAdjustments = map[Standing]int{
+		StandingUnfriendly: 25,
+		StandingFriendly:   -10,
+		StandingHonored:    -20,
+	}
+)
+
+// Converts a reputation value into a standing
+func GetStanding(reputation int) Standing {
+	if reputation <= -500 {
+		return StandingHated
+	}
+	if reputation <= -100 {
+		return StandingUnfriendly
+	}
+	if reputation >= 500 {
+		return StandingHonored
+	}
+	if reputation >= 100 {
+		return StandingFriendly
+	}
+	return StandingNeutral
+}
+
+func (s Standing) String() string {
+	return standingNames[s]
+}
+
+// Returns an ansi color alias suitable for displaying the standing
+func (s Standing) Color() string {
+	return standingColors[s]
+}
+
+// Members attack on sight
+func (s Standing) IsHated() bool {
+	return s <= StandingHated
+}
+
+// Members leave the character alone, even if they usually attack on sight
+func (s Standing) IsFriendly() bool {
+	return s >= StandingFriendly
+}
+
+// Whether merchants of the faction will buy from or sell to the character
+func (s Standing) WillTrade() bool {
+	return s > StandingHated
+}
+
+// What a merchant of the faction charges the character for something
+func (s Standing) BuyPrice(price int) int {
+	return price + (price*standingPriceAdjustments[s])/100
+}
+
+// What a merchant of the faction pays the character for something
+func (s Standing) SellPrice(price int) int {
+	return price - (price*standingPriceAdjustments[s])/100
+}
@@ -0,0 +1,114 @@
+package items
+
+const (
+	// Max durability of weapons and wearables that don't set their own
+	defaultDurability = 100
+	// Below this percent of durability, items start to lose effectiveness
+	wornDurabilityPct = 50
+)
+
+// Whether the item is something worn or wielded
+func (i *ItemSpec) IsGear() bool {
+	return i.Type == Weapon || i.Subtype == Wearable
+}
+
+// How much wear the item can take before it breaks.
+// Zero means the item doesn't wear out.
+func (i *Item) MaxDurability() int {
+	if i.ItemId < 1 {
+		return 0
+	}
+
+	spec := i.GetSpec()
+	if spec.Durability < 0 {
+		return 0
+	}
+	if spec.Durability == 0 && spec.IsGear() {
+		return defaultDurability // default
+	}
+
+	return spec.Durability
+}
+
+// How much wear the item can still take before it breaks
+func (i *Item) Durability() int {
+	d := i.MaxDurability() - i.Wear
+	if d < 0 {
+		return 0
+	}
+	return d
+}
+
+func (i *Item) IsBroken() bool {
+	return i.MaxDurability() > 0 && i.Durability() == 0
+}
+
+// Wears the item down.
+// Returns true if this broke the item.
+func (i *Item) AddWear(amount int) bool {
+
+	if amount < 1 || i.MaxDurability() < 1 || i.IsBroken() {
+		return false
+	}
+
+	i.Wear += amount
+	if i.Wear > i.MaxDurability() {
+		i.Wear = i.MaxDurability()
+	}
+
+	return i.IsBroken()
+}
+
+// Restores durability to the item.
+// Returns how much was restored.
+func (i *Item) Repair(amount int) int {
+
+	if amount > i.Wear {
+		amount = i.Wear
+	}
+	i.Wear -= amount
+
+	return amount
+}
+
+// Percent (0-100) of its damage and defense the item provides in its current condition.
+// Items keep full effectiveness until they are badly worn, and provide nothing once broken.
+func (i *Item) Effectiveness() int {
+
+	maxDurability := i.MaxDurability()
+	if maxDurability < 1 {
+		return 100
+	}
+
+	if i.IsBroken() {
+		return 0
+	}
+
+	pct := i.Durability() * 100 / maxDurability
+	if pct >= wornDurabilityPct {
+		return 100
+	}
+
+	return 100 - (wornDurabilityPct - pct)
+}
+
+// What a merchant charges to fully restore the item, before any haggling.
+// Broken items cost extra to mend.
+func (i *Item) RepairCost() int {
+
+	maxDurability := i.MaxDurability()
+	if maxDurability < 1 || i.Wear < 1 {
+		return 0
+	}
+
+	cost := i.GetSpec().Value * i.Wear / maxDurability / 2
+	if i.IsBroken() {
+		cost += i.GetSpec().Value / 4
+	}
+
+	if cost < 1 {
+		cost = 1
+	}
+
+	return cost
+}
@@ -0,0 +1,93 @@
+package items
+
+import "testing"
+
+func testGear(durability int, value int, wear int) Item {
+	return Item{
+		ItemId: 1,
+		Wear:   wear,
+		Spec:   &ItemSpec{Type: Weapon, Durability: durability, Value: value},
+	}
+}
+
+func TestAddWear(t *testing.T) {
+
+	itm := testGear(10, 100, 0)
+
+	if itm.AddWear(4) || itm.Wear != 4 || itm.Durability() != 6 {
+		t.Errorf("AddWear(4): expected wear 4 and durability 6, got wear %d and durability %d", itm.Wear, itm.Durability())
+	}
+
+	if !itm.AddWear(20) || itm.Wear != 10 || !itm.IsBroken() {
+		t.Errorf("AddWear(20): expected the item to break at wear 10, got wear %d", itm.Wear)
+	}
+
+	if itm.AddWear(1) {
+		t.Errorf("AddWear(1): an item that is already broken can't break again")
+	}
+
+	unbreakable := testGear(-1, 100, 0)
+	if unbreakable.AddWear(50) || unbreakable.Wear != 0 {
+		t.Errorf("AddWear(50): expected an item with negative durability not to wear, got wear %d", unbreakable.Wear)
+	}
+
+	defaulted := testGear(0, 100, 0)
+	if defaulted.MaxDurability() != defaultDurability {
+		t.Errorf("MaxDurability(): expected gear without a durability to default to %d, got %d", defaultDurability, defaulted.MaxDurability())
+	}
+}
+
+func TestRepair(t *testing.T) {
+
+	itm := testGear(10, 100, 8)
+
+	if restored := itm.Repair(3); restored != 3 || itm.Wear != 5 {
+		t.Errorf("Repair(3): expected 3 restored and wear 5, got %d restored and wear %d", restored, itm.Wear)
+	}
+
+	if restored := itm.Repair(50); restored != 5 || itm.Wear != 0 {
+		t.Errorf("Repair(50): expected 5 restored and wear 0, got %d restored and wear %d", restored, itm.Wear)
+	}
+}
+
+func TestEffectiveness(t *testing.T) {
+	tests := []struct {
+		durability int
+		wear       int
+		expected   int
+	}{
+		{100, 0, 100},
+		{100, 50, 100},  // Half worn is still fully effective
+		{100, 70, 80},   // 30% left is 20 below the worn threshold
+		{100, 100, 0},   // Broken
+		{0, 75, 75},     // Defaults to 100
+		{-1, 1000, 100}, // Never wears out
+	}
+
+	for _, test := range tests {
+		itm := testGear(test.durability, 100, test.wear)
+		if got := itm.Effectiveness(); got != test.expected {
+			t.Errorf("Effectiveness(): durability %d wear %d: expected %d, got %d", test.durability, test.wear, test.expected, got)
+		}
+	}
+}
+
+func TestRepairCost(t *testing.T) {
+	tests := []struct {
+		value    int
+		wear     int
+		expected int
+	}{
+		{100, 0, 0},    // Nothing to repair
+		{100, 50, 25},  // Half the value for full wear, so a quarter for half
+		{100, 100, 75}, // Broken items cost a quarter of their value extra
+		{1, 10, 1},     // Always costs something
+	}
+
+	for _, test := range tests {
+		itm := testGear(100, test.value, test.wear)
+		if got := itm.RepairCost(); got != test.expected {
+			t.Errorf("RepairCost(): value %d wear %d: expected %d, got %d", test.value, test.wear, test.expected, got)
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"unicode"
 
 	"github.com/volte6/gomud/colorpatterns"
+	"github.com/volte6/gomud/statmods"
 	"github.com/volte6/gomud/util"
 )
 
@@ -26,6 +27,14 @@ var (
 		// Is the item exploding?
 		`exploding`:       `<ansi fg="red">!!!Exploding!!!</ansi>`,
 		`exploding-short`: `<ansi fg="red">!!!/ansi>`,
+		// Is the item worn out?
+		`broken`:       `<ansi fg="item-broken">broken</ansi>`,
+		`broken-short`: `<ansi fg="item-broken">x</ansi>`,
+		// Loot rarity tiers
+		`uncommon`:  `<ansi fg="rarity-uncommon">uncommon</ansi>`,
+		`rare`:      `<ansi fg="rarity-rare">rare</ansi>`,
+		`epic`:      `<ansi fg="rarity-epic">epic</ansi>`,
+		`legendary`: `<ansi fg="rarity-legendary">legendary</ansi>`,
 	}
 )
 
@@ -41,6 +50,7 @@ type Item struct {
 	uid           uint64         `yaml:"-"`
 	Blob          string         `yaml:"blob,omitempty"`          // Does this item have a blob? Should be base64 encoded.
 	Uses          int            `yaml:"uses,omitempty"`          // How many uses it has left
+	Wear          int            `yaml:"wear,omitempty"`          // How much durability it has lost
 	LastUsedRound uint64         `yaml:"lastusedround,omitempty"` // Last round this item was used
 	Spec          *ItemSpec      `yaml:"overrides,omitempty"`
 	Uncursed      bool           `yaml:"uncursed,omitempty"`     // Is this item uncursed?
@@ -324,6 +334,13 @@ func (i *Item) Enchant(damageBonus int, defenseBonus int, statBonus map[string]i
 	newSpec.Damage.BonusDamage += damageBonus
 	newSpec.DamageReduction += defenseBonus
 
+	// The copied spec still shares its statmods with the original, so copy those too
+	newStatMods := statmods.StatMods{}
+	for statName, statAmt := range newSpec.StatMods {
+		newStatMods[statName] = statAmt
+	}
+	newSpec.StatMods = newStatMods
+
 	// Permanently add new statmods
 	for statName, statBonusAmt := range statBonus {
 		newSpec.StatMods.Add(statName, statBonusAmt)
@@ -377,9 +394,10 @@ func (i *Item) GetDamage() Damage {
 }
 
 // Returns a random number up to the total possible reduction for this item.
+// Worn down items provide less.
 func (i *Item) GetDefense() int {
 	itemInfo := i.GetSpec()
-	return itemInfo.DamageReduction
+	return itemInfo.DamageReduction * i.Effectiveness() / 100
 }
 
 func (i *Item) Equals(b Item) bool {
@@ -466,10 +484,15 @@ func (i *Item) DisplayName() string {
 		prefix = `<ansi fg="questflag">★</ansi>`
 	}
 
+	adjectives := i.Adjectives
+	if i.IsBroken() {
+		adjectives = append([]string{`broken`}, adjectives...)
+	}
+
 	suffix := ``
-	if adjLen := len(i.Adjectives); adjLen > 0 {
+	if adjLen := len(adjectives); adjLen > 0 {
 		suffix += ` <ansi fg="black-bold">(`
-		for i, adj := range i.Adjectives {
+		for i, adj := range adjectives {
 			if newAdj, ok := adjectiveSwaps[adj]; ok {
 				suffix += newAdj
 			} else {
@@ -579,7 +602,7 @@ func (i *Item) NameMatch(input string, allowContains bool) (partialMatch bool, f
 
 func (i *Item) StatMod(statName ...string) int {
 
-	if i.ItemId < 1 {
+	if i.ItemId < 1 || i.IsBroken() {
 		return 0
 	}
 
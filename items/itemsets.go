@@ -0,0 +1,137 @@
+package items
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/volte6/gomud/fileloader"
+	"github.com/volte6/gomud/statmods"
+)
+
+var (
+	itemSets             = map[string]*ItemSet{}
+	itemSetsByItemId     = map[int]*ItemSet{}
+	itemSetDataFilesPath = "_datafiles/itemsets"
+)
+
+// What wearing enough pieces of a set grants
+type SetBonus struct {
+	Description string            `yaml:"description,omitempty"` // Shown when inspecting a piece of the set
+	StatMods    statmods.StatMods `yaml:"statmods,omitempty"`    // Extra stat mods while enough pieces are worn
+	WornBuffIds []int             `yaml:"wornbuffids,omitempty"` // Buffs applied while enough pieces are worn
+}
+
+type ItemSet struct {
+	ItemSetId string           `yaml:"itemsetid"` // Unique id of the set ("spider-queen")
+	Name      string           `yaml:"name"`      // Name shown to players ("Regalia of the Spider Queen")
+	ItemIds   []int            `yaml:"itemids"`   // Items that belong to the set
+	Bonuses   map[int]SetBonus `yaml:"bonuses"`   // Bonuses keyed by how many pieces must be worn (2, 3 or 4)
+}
+
+func (s *ItemSet) Id() string {
+	return s.ItemSetId
+}
+
+func (s *ItemSet) Filepath() string {
+	return fmt.Sprintf("%s.yaml", s.ItemSetId)
+}
+
+func (s *ItemSet) Validate() error {
+
+	s.ItemSetId = strings.ToLower(s.ItemSetId)
+
+	if s.Name == `` {
+		return fmt.Errorf(`item set %s: missing name`, s.ItemSetId)
+	}
+
+	if len(s.ItemIds) < 2 {
+		return fmt.Errorf(`item set %s: needs at least 2 items`, s.ItemSetId)
+	}
+
+	for pieces := range s.Bonuses {
+		if pieces < 2 || pieces > 4 {
+			return fmt.Errorf(`item set %s: bonuses must be for 2, 3 or 4 pieces, not %d`, s.ItemSetId, pieces)
+		}
+		if pieces > len(s.ItemIds) {
+			return fmt.Errorf(`item set %s: bonus for %d pieces but the set only has %d items`, s.ItemSetId, pieces, len(s.ItemIds))
+		}
+	}
+
+	return nil
+}
+
+// Piece counts that grant a bonus, lowest first
+func (s *ItemSet) BonusTiers() []int {
+	tiers := []int{}
+	for pieces := range s.Bonuses {
+		tiers = append(tiers, pieces)
+	}
+	sort.Ints(tiers)
+	return tiers
+}
+
+// How many different pieces of the set are among the item ids provided
+func (s *ItemSet) CountPieces(itemIds ...int) int {
+
+	found := map[int]struct{}{}
+	for _, itemId := range itemIds {
+		for _, setItemId := range s.ItemIds {
+			if itemId == setItemId {
+				found[itemId] = struct{}{}
+				break
+			}
+		}
+	}
+
+	return len(found)
+}
+
+// Every bonus earned by wearing a given number of pieces
+func (s *ItemSet) ActiveBonuses(piecesWorn int) []SetBonus {
+	active := []SetBonus{}
+	for _, pieces := range s.BonusTiers() {
+		if piecesWorn >= pieces {
+			active = append(active, s.Bonuses[pieces])
+		}
+	}
+	return active
+}
+
+func GetItemSet(itemSetId string) *ItemSet {
+	return itemSets[strings.ToLower(itemSetId)]
+}
+
+// Returns the set an item belongs to, or nil
+func GetItemSetFor(itemId int) *ItemSet {
+	return itemSetsByItemId[itemId]
+}
+
+func GetAllItemSets() []*ItemSet {
+	ret := []*ItemSet{}
+	for _, s := range itemSets {
+		ret = append(ret, s)
+	}
+	return ret
+}
+
+func loadItemSets() {
+
+	var err error
+	itemSets, err = fileloader.LoadAllFlatFiles[string, *ItemSet](itemSetDataFilesPath)
+	if err != nil {
+		panic(err)
+	}
+
+	itemSetsByItemId = map[int]*ItemSet{}
+	for _, s := range itemSets {
+		for _, itemId := range s.ItemIds {
+			if other, ok := itemSetsByItemId[itemId]; ok {
+				slog.Error("items.loadItemSets()", "itemId", itemId, "error", fmt.Sprintf(`item is in both the %s and %s sets`, other.ItemSetId, s.ItemSetId))
+				continue
+			}
+			itemSetsByItemId[itemId] = s
+		}
+	}
+}
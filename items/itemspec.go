@@ -60,6 +60,8 @@ const (
 	Gemstone  ItemType = "gemstone"  // A gem
 	Lockpicks ItemType = "lockpicks" // Used for lockpicking
 	Botanical ItemType = "botanical" // A plant, herb, etc.
+	Deed      ItemType = "deed"      // Deed to a home, used in a housing district
+	Boat      ItemType = "boat"      // Lets the carrier travel over deep water
 
 	// Subtypes for wearables
 	Wearable  ItemSubType = "wearable"
@@ -155,6 +157,7 @@ type ItemSpec struct {
 	Damage          Damage
 	Element         Element
 	StatMods        statmods.StatMods `yaml:"statmods,omitempty"`    // What stats it modifies when equipped
+	Durability      int               `yaml:"durability,omitempty"`  // How much wear it can take before breaking. Weapons and wearables default to 100, negative never wears out
 	BreakChance     uint8             `yaml:"breakchance,omitempty"` // Chance in 100 that the item will break when used, or when the character is hit with it equipped, or if it is in the characters inventory during an explosion, etc.
 	Cursed          bool              `yaml:"cursed,omitempty"`      // Can't be removed once equipped
 	KeyLockId       string            `yaml:"keylockid,omitempty"`   // Example: `778-north` - If it's a key, what lock does it open? roomid-exitname etc.
@@ -308,7 +311,6 @@ func (i *ItemSpec) Validate() error {
 	if i.Value < 1 {
 		i.AutoCalculateValue()
 	}
-
 	return nil
 }
 
@@ -383,6 +385,8 @@ func LoadDataFiles() {
 		panic(err)
 	}
 
-	slog.Info("itemspec.LoadDataFiles()", "itemLoadedCount", len(items), "attackMessageCount", len(attackMessages), "Time Taken", time.Since(start))
+	loadItemSets()
+
+	slog.Info("itemspec.LoadDataFiles()", "itemLoadedCount", len(items), "attackMessageCount", len(attackMessages), "itemSetCount", len(itemSets), "Time Taken", time.Since(start))
 
 }
@@ -0,0 +1,269 @@
+package leaderboards
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/volte6/gomud/achievements"
+	"github.com/volte6/gomud/quests"
+	"github.com/volte6/gomud/users"
+)
+
+const (
+	// How many characters are ranked on each leaderboard
+	BoardSize = 10
+)
+
+type Category string
+
+const (
+	CategoryLevel        Category = `level`
+	CategoryKills        Category = `kills`
+	CategoryPvP          Category = `pvp`
+	CategoryWealth       Category = `wealth`
+	CategoryQuests       Category = `quests`
+	CategoryAchievements Category = `achievements`
+)
+
+var (
+	// Categories in the order they are displayed
+	Categories = []Category{
+		CategoryLevel,
+		CategoryKills,
+		CategoryPvP,
+		CategoryWealth,
+		CategoryQuests,
+		CategoryAchievements,
+	}
+
+	categoryTitles = map[Category]string{
+		CategoryLevel:        `Experience`,
+		CategoryKills:        `Mob Kills`,
+		CategoryPvP:          `PvP Kills/Deaths`,
+		CategoryWealth:       `Wealth`,
+		CategoryQuests:       `Quests Completed`,
+		CategoryAchievements: `Achievement Points`,
+	}
+
+	lock        = sync.RWMutex{}
+	boards      = map[Category]Board{}
+	ladder      = []LadderEntry{}
+	lastUpdated time.Time
+	updating    atomic.Bool // Whether a rebuild is already underway
+)
+
+type Entry struct {
+	CharacterName string
+	Value         int    // What the board is sorted by
+	Details       string // How the value is displayed
+}
+
+type Board struct {
+	Category Category
+	Title    string
+	Entries  []Entry
+}
+
+// A ranked arena fighter
+type LadderEntry struct {
+	Name   string
+	Rating int
+	Wins   int
+	Losses int
+}
+
+// Returns a copy of the leaderboard for a category
+func Get(category Category) (Board, bool) {
+	lock.RLock()
+	defer lock.RUnlock()
+
+	b, ok := boards[category]
+	if !ok {
+		return Board{}, false
+	}
+	b.Entries = append([]Entry{}, b.Entries...)
+
+	return b, true
+}
+
+// Returns a copy of every leaderboard, in display order
+func GetAll() []Board {
+	all := []Board{}
+	for _, category := range Categories {
+		if b, ok := Get(category); ok {
+			all = append(all, b)
+		}
+	}
+	return all
+}
+
+// Returns a copy of the highest rated arena fighters
+func GetLadder() []LadderEntry {
+	lock.RLock()
+	defer lock.RUnlock()
+
+	return append([]LadderEntry{}, ladder...)
+}
+
+// When the leaderboards were last rebuilt
+func LastUpdated() time.Time {
+	lock.RLock()
+	defer lock.RUnlock()
+
+	return lastUpdated
+}
+
+// Rebuilds every leaderboard, and the arena ladder, from online and offline characters.
+// Online characters are read right away, but offline characters are read from disk in the background,
+// and the new leaderboards replace the old ones once they're done. Does nothing if a rebuild is already underway.
+func Update() {
+
+	if !updating.CompareAndSwap(false, true) {
+		return
+	}
+
+	allEntries := map[Category][]Entry{}
+	ladderEntries := []LadderEntry{}
+	onlineUsernames := map[string]struct{}{}
+
+	addUser := func(u *users.UserRecord) {
+		for category, entry := range getEntries(u) {
+			allEntries[category] = append(allEntries[category], entry)
+		}
+		if u.Character.PvP.GetMatchCount() > 0 {
+			ladderEntries = append(ladderEntries, LadderEntry{u.Character.Name, u.Character.PvP.GetRating(), u.Character.PvP.Wins, u.Character.PvP.Losses})
+		}
+	}
+
+	for _, u := range users.GetAllActiveUsers() {
+		onlineUsernames[u.Username] = struct{}{}
+		addUser(u)
+	}
+
+	go func() {
+
+		defer updating.Store(false)
+
+		start := time.Now()
+
+		users.SearchUserFiles(func(u *users.UserRecord) bool {
+			if _, ok := onlineUsernames[u.Username]; !ok {
+				addUser(u)
+			}
+			return true
+		})
+
+		newBoards := buildBoards(allEntries)
+		newLadder := buildLadder(ladderEntries)
+
+		lock.Lock()
+		boards = newBoards
+		ladder = newLadder
+		lastUpdated = time.Now()
+		lock.Unlock()
+
+		slog.Info("leaderboards.Update()", "Time Taken", time.Since(start))
+	}()
+}
+
+// Ranks arena fighters by rating, keeping the top of the ladder
+func buildLadder(entries []LadderEntry) []LadderEntry {
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Rating == entries[j].Rating {
+			return entries[i].Wins > entries[j].Wins
+		}
+		return entries[i].Rating > entries[j].Rating
+	})
+
+	if len(entries) > BoardSize {
+		entries = entries[:BoardSize]
+	}
+
+	return entries
+}
+
+// Sorts everyone's entries into leaderboards, keeping the top of each
+func buildBoards(allEntries map[Category][]Entry) map[Category]Board {
+
+	newBoards := map[Category]Board{}
+
+	for _, category := range Categories {
+
+		entries := allEntries[category]
+
+		sort.SliceStable(entries, func(i, j int) bool {
+			if entries[i].Value == entries[j].Value {
+				return entries[i].CharacterName < entries[j].CharacterName
+			}
+			return entries[i].Value > entries[j].Value
+		})
+
+		if len(entries) > BoardSize {
+			entries = entries[:BoardSize]
+		}
+
+		newBoards[category] = Board{
+			Category: category,
+			Title:    categoryTitles[category],
+			Entries:  entries,
+		}
+	}
+
+	return newBoards
+}
+
+// Returns where a user stands in each category they qualify for
+func getEntries(u *users.UserRecord) map[Category]Entry {
+
+	c := u.Character
+	entries := map[Category]Entry{}
+
+	// Hardly worth ranking anyone who hasn't left the starting area
+	if c.Level < 2 && c.Experience == 0 {
+		return entries
+	}
+
+	entries[CategoryLevel] = Entry{
+		CharacterName: c.Name,
+		Value:         c.Experience,
+		Details:       fmt.Sprintf(`Level %d (%d xp)`, c.Level, c.Experience),
+	}
+
+	if kills := c.KD.GetMobKills(); kills > 0 {
+		entries[CategoryKills] = Entry{c.Name, kills, fmt.Sprintf(`%d`, kills)}
+	}
+
+	if c.KD.PlayerKills > 0 {
+		// Players who haven't been cut down rank by kills alone
+		ratio := c.KD.GetPvPKDRatio()
+		entries[CategoryPvP] = Entry{c.Name, int(ratio * 100), fmt.Sprintf(`%.2f (%d/%d)`, ratio, c.KD.PlayerKills, c.KD.PlayerDeaths)}
+	}
+
+	if wealth := c.Gold + c.Bank; wealth > 0 {
+		entries[CategoryWealth] = Entry{c.Name, wealth, fmt.Sprintf(`%d gold`, wealth)}
+	}
+
+	questCt := 0
+	for questId, stepId := range c.QuestProgress {
+		if stepId != `end` {
+			continue
+		}
+		if questInfo := quests.GetQuest(quests.PartsToToken(questId, `end`)); questInfo != nil && !questInfo.Secret {
+			questCt++
+		}
+	}
+	if questCt > 0 {
+		entries[CategoryQuests] = Entry{c.Name, questCt, fmt.Sprintf(`%d`, questCt)}
+	}
+
+	if points := achievements.GetPoints(c.Achievements); points > 0 {
+		entries[CategoryAchievements] = Entry{c.Name, points, fmt.Sprintf(`%d`, points)}
+	}
+
+	return entries
+}
@@ -0,0 +1,226 @@
+package loot
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/volte6/gomud/fileloader"
+	"github.com/volte6/gomud/items"
+	"github.com/volte6/gomud/util"
+)
+
+const (
+	// Nested tables deeper than this are ignored, in case tables reference each other
+	maxTableDepth = 5
+)
+
+var (
+	allLootTables     = map[string]*LootTable{}
+	lootDataFilesPath = "_datafiles/loot"
+)
+
+type LootEntry struct {
+	ItemId   int    `yaml:"itemid,omitempty"`   // Item to drop
+	LootId   string `yaml:"lootid,omitempty"`   // Another loot table to roll on instead of an item
+	Quantity int    `yaml:"quantity,omitempty"` // How many of the item to drop. Defaults to 1
+	Weight   int    `yaml:"weight,omitempty"`   // Chance of being picked, relative to the other entries. Defaults to 1
+	Rarity   Rarity `yaml:"rarity,omitempty"`   // Always drop at this rarity instead of rolling for it (optional)
+}
+
+type LootTable struct {
+	LootId     string         `yaml:"lootid"`               // Unique id of the loot table ("rats-common")
+	Rolls      int            `yaml:"rolls,omitempty"`      // How many entries are picked each time the table is rolled. Defaults to 1
+	GoldMin    int            `yaml:"goldmin,omitempty"`    // Least gold dropped
+	GoldMax    int            `yaml:"goldmax,omitempty"`    // Most gold dropped
+	LevelScale int            `yaml:"levelscale,omitempty"` // Percent per level that gold and the chance of better rarity go up
+	Rarity     map[Rarity]int `yaml:"rarity,omitempty"`     // Overrides the default weight of each rarity tier, such as "legendary: 1"
+	Entries    []LootEntry    `yaml:"entries"`              // An entry with no item or table id is a chance of nothing
+}
+
+// What a loot table roll produced
+type Drop struct {
+	Items []items.Item
+	Gold  int
+}
+
+func (l *LootTable) Id() string {
+	return l.LootId
+}
+
+func (l *LootTable) Filepath() string {
+	return fmt.Sprintf("%s.yaml", l.LootId)
+}
+
+func (l *LootTable) Validate() error {
+
+	l.LootId = strings.ToLower(l.LootId)
+
+	if l.Rolls < 1 {
+		l.Rolls = 1 // default
+	}
+
+	if l.GoldMax < l.GoldMin {
+		l.GoldMax = l.GoldMin
+	}
+
+	for r := range l.Rarity {
+		if !r.IsValid() {
+			return fmt.Errorf(`loot table %s: unknown rarity: %s`, l.LootId, r)
+		}
+	}
+
+	for i := range l.Entries {
+
+		l.Entries[i].LootId = strings.ToLower(l.Entries[i].LootId)
+
+		if l.Entries[i].LootId == l.LootId {
+			return fmt.Errorf(`loot table %s: entry references its own table`, l.LootId)
+		}
+
+		if l.Entries[i].Rarity != `` && !l.Entries[i].Rarity.IsValid() {
+			return fmt.Errorf(`loot table %s: unknown rarity: %s`, l.LootId, l.Entries[i].Rarity)
+		}
+
+		if l.Entries[i].Quantity < 1 {
+			l.Entries[i].Quantity = 1 // default
+		}
+
+		if l.Entries[i].Weight < 1 {
+			l.Entries[i].Weight = 1 // default
+		}
+	}
+
+	return nil
+}
+
+// Rolls the table for something killed, found or earned at a given level.
+func (l *LootTable) Roll(level int) Drop {
+	drop := Drop{Items: []items.Item{}}
+	l.roll(level, 0, &drop)
+	return drop
+}
+
+func (l *LootTable) roll(level int, depth int, drop *Drop) {
+
+	if depth > maxTableDepth {
+		slog.Error("LootTable.Roll()", "lootId", l.LootId, "error", "nested too deeply")
+		return
+	}
+
+	if level < 1 {
+		level = 1
+	}
+	levelScale := 100 + (level-1)*l.LevelScale
+
+	if l.GoldMax > 0 {
+		gold := l.GoldMin + util.Rand(l.GoldMax-l.GoldMin+1)
+		drop.Gold += gold * levelScale / 100
+	}
+
+	totalWeight := 0
+	for _, entry := range l.Entries {
+		totalWeight += entry.Weight
+	}
+
+	for i := 0; i < l.Rolls; i++ {
+
+		roll := util.Rand(totalWeight)
+
+		for _, entry := range l.Entries {
+
+			if roll >= entry.Weight {
+				roll -= entry.Weight
+				continue
+			}
+
+			if entry.LootId != `` {
+				if nestedTable := GetLootTable(entry.LootId); nestedTable != nil {
+					nestedTable.roll(level, depth+1, drop)
+				}
+				break
+			}
+
+			if entry.ItemId == 0 {
+				break // Nothing this time
+			}
+
+			for q := 0; q < entry.Quantity; q++ {
+
+				item := items.New(entry.ItemId)
+				if item.ItemId == 0 {
+					break
+				}
+
+				if CanHaveRarity(item) {
+					rarity := entry.Rarity
+					if rarity == `` {
+						rarity = rollRarity(l.Rarity, levelScale)
+					}
+					ApplyRarity(&item, rarity)
+				}
+
+				drop.Items = append(drop.Items, item)
+			}
+
+			break
+		}
+	}
+
+}
+
+func GetLootTable(lootId string) *LootTable {
+	if l, ok := allLootTables[strings.ToLower(lootId)]; ok {
+		return l
+	}
+	return nil
+}
+
+// Rolls a loot table by id. Unknown tables drop nothing.
+func Roll(lootId string, level int) Drop {
+	if l := GetLootTable(lootId); l != nil {
+		return l.Roll(level)
+	}
+	slog.Error("loot.Roll()", "lootId", lootId, "error", "loot table not found")
+	return Drop{Items: []items.Item{}}
+}
+
+// Makes sure every item and nested table the loot tables refer to exists.
+// Items must be loaded first.
+func validateReferences(lootTables map[string]*LootTable) error {
+
+	for _, l := range lootTables {
+		for _, entry := range l.Entries {
+
+			if entry.LootId != `` {
+				if _, ok := lootTables[entry.LootId]; !ok {
+					return fmt.Errorf(`loot table %s: unknown loot table: %s`, l.LootId, entry.LootId)
+				}
+			}
+
+			if entry.ItemId != 0 && items.GetItemSpec(entry.ItemId) == nil {
+				return fmt.Errorf(`loot table %s: unknown item: %d`, l.LootId, entry.ItemId)
+			}
+		}
+	}
+
+	return nil
+}
+
+func LoadDataFiles() {
+
+	start := time.Now()
+
+	var err error
+	allLootTables, err = fileloader.LoadAllFlatFiles[string, *LootTable](lootDataFilesPath)
+	if err != nil {
+		panic(err)
+	}
+
+	if err = validateReferences(allLootTables); err != nil {
+		panic(err)
+	}
+
+	slog.Info("loot.LoadDataFiles()", "loadedCount", len(allLootTables), "Time Taken", time.Since(start))
+}
@@ -0,0 +1,76 @@
+package loot
+
+import "testing"
+
+func TestLootTableValidate(t *testing.T) {
+
+	l := LootTable{
+		LootId:  `Test-Table`,
+		GoldMin: 10,
+		GoldMax: 5,
+		Entries: []LootEntry{{ItemId: 1}, {LootId: `Gems`, Weight: 3}},
+	}
+
+	if err := l.Validate(); err != nil {
+		t.Fatalf("Validate(): unexpected error: %s", err)
+	}
+
+	if l.LootId != `test-table` || l.Rolls != 1 || l.GoldMax != 10 {
+		t.Errorf("Validate(): expected defaults, got id=%s rolls=%d goldmax=%d", l.LootId, l.Rolls, l.GoldMax)
+	}
+
+	if l.Entries[0].Weight != 1 || l.Entries[0].Quantity != 1 || l.Entries[1].LootId != `gems` {
+		t.Errorf("Validate(): expected entry defaults, got %+v", l.Entries)
+	}
+
+	badTables := []LootTable{
+		{LootId: `loop`, Entries: []LootEntry{{LootId: `loop`}}},
+		{LootId: `badrarity`, Rarity: map[Rarity]int{`shiny`: 1}},
+		{LootId: `badentry`, Entries: []LootEntry{{ItemId: 1, Rarity: `shiny`}}},
+	}
+
+	for _, bad := range badTables {
+		if err := bad.Validate(); err == nil {
+			t.Errorf("Validate(%s): expected an error", bad.LootId)
+		}
+	}
+}
+
+func TestValidateReferences(t *testing.T) {
+
+	tables := map[string]*LootTable{
+		`gems`:  {LootId: `gems`},
+		`hoard`: {LootId: `hoard`, Entries: []LootEntry{{LootId: `gems`}, {}}},
+	}
+
+	if err := validateReferences(tables); err != nil {
+		t.Errorf("validateReferences(): unexpected error: %s", err)
+	}
+
+	tables[`broken`] = &LootTable{LootId: `broken`, Entries: []LootEntry{{LootId: `missing`}}}
+
+	if err := validateReferences(tables); err == nil {
+		t.Errorf("validateReferences(): expected an error for a missing nested table")
+	}
+}
+
+func TestRollRarity(t *testing.T) {
+	tests := []struct {
+		weights    map[Rarity]int
+		levelScale int
+		expected   Rarity
+	}{
+		{map[Rarity]int{Common: 0, Uncommon: 0, Rare: 0, Epic: 5}, 100, Epic},
+		{map[Rarity]int{Common: 0, Uncommon: 0, Rare: 0, Epic: 0, Legendary: 1}, 300, Legendary},
+		{map[Rarity]int{Uncommon: 0, Rare: 0, Epic: 0}, 1000, Common},
+	}
+
+	for _, test := range tests {
+		for i := 0; i < 20; i++ {
+			if r := rollRarity(test.weights, test.levelScale); r != test.expected {
+				t.Errorf("rollRarity(%v, %d): expected %s, got %s", test.weights, test.levelScale, test.expected, r)
+				break
+			}
+		}
+	}
+}
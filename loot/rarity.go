@@ -0,0 +1,111 @@
+package loot
+
+import (
+	"github.com/volte6/gomud/items"
+	"github.com/volte6/gomud/util"
+)
+
+type Rarity string
+
+const (
+	Common    Rarity = `common`
+	Uncommon  Rarity = `uncommon`
+	Rare      Rarity = `rare`
+	Epic      Rarity = `epic`
+	Legendary Rarity = `legendary`
+)
+
+type rarityTier struct {
+	Weight    int // Default chance of rolling this tier, relative to the others
+	StatCount int // How many random stats get a bonus
+	StatMin   int // Smallest bonus per stat
+	StatMax   int // Largest bonus per stat
+	GearBonus int // Bonus to weapon damage or armor defense
+}
+
+var (
+	// Tiers from worst to best
+	rarityOrder = []Rarity{Common, Uncommon, Rare, Epic, Legendary}
+
+	rarityTiers = map[Rarity]rarityTier{
+		Common:    {Weight: 100},
+		Uncommon:  {Weight: 25, StatCount: 1, StatMin: 1, StatMax: 2},
+		Rare:      {Weight: 8, StatCount: 2, StatMin: 1, StatMax: 3, GearBonus: 1},
+		Epic:      {Weight: 2, StatCount: 3, StatMin: 2, StatMax: 4, GearBonus: 2},
+		Legendary: {Weight: 0, StatCount: 4, StatMin: 3, StatMax: 5, GearBonus: 3}, // Tables must opt in to legendary drops
+	}
+
+	rarityStats = []string{`strength`, `speed`, `smarts`, `vitality`, `mysticism`, `perception`}
+)
+
+func (r Rarity) IsValid() bool {
+	_, ok := rarityTiers[r]
+	return ok
+}
+
+// Whether an item can be improved by rarity.
+// Only things that are worn or wielded benefit from enchantments.
+func CanHaveRarity(i items.Item) bool {
+	spec := i.GetSpec()
+	return spec.IsGear()
+}
+
+// Picks a rarity tier using the table weights, falling back on the default weight of each tier.
+// levelScale is a percentage (100 = unchanged) applied to the weight of every tier above common.
+func rollRarity(weights map[Rarity]int, levelScale int) Rarity {
+
+	totalWeight := 0
+	tierWeights := make([]int, len(rarityOrder))
+
+	for idx, r := range rarityOrder {
+
+		w := rarityTiers[r].Weight
+		if tableWeight, ok := weights[r]; ok {
+			w = tableWeight
+		}
+
+		if r != Common {
+			w = w * levelScale / 100
+		}
+
+		tierWeights[idx] = w
+		totalWeight += w
+	}
+
+	roll := util.Rand(totalWeight)
+	for idx, w := range tierWeights {
+		if roll < w {
+			return rarityOrder[idx]
+		}
+		roll -= w
+	}
+
+	return Common
+}
+
+// Enchants an item with random bonuses for its rarity and marks it with the tier name.
+// Common items, and items that can't be worn or wielded, are left alone.
+func ApplyRarity(i *items.Item, r Rarity) {
+
+	tier, ok := rarityTiers[r]
+	if !ok || r == Common || !CanHaveRarity(*i) {
+		return
+	}
+
+	damageBonus := 0
+	defenseBonus := 0
+	if i.GetSpec().Type == items.Weapon {
+		damageBonus = tier.GearBonus
+	} else {
+		defenseBonus = tier.GearBonus
+	}
+
+	statBonus := map[string]int{}
+	for j := 0; j < tier.StatCount; j++ {
+		chosenStat := rarityStats[util.Rand(len(rarityStats))]
+		statBonus[chosenStat] += tier.StatMin + util.Rand(tier.StatMax-tier.StatMin+1)
+	}
+
+	i.Enchant(damageBonus, defenseBonus, statBonus, false)
+	i.SetAdjective(string(r), true)
+}
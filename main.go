@@ -21,23 +21,32 @@ import (
 	"github.com/Volte6/ansitags"
 	"github.com/gorilla/websocket"
 	"github.com/natefinch/lumberjack"
+	"github.com/volte6/gomud/achievements"
 	"github.com/volte6/gomud/buffs"
+	"github.com/volte6/gomud/channels"
 	"github.com/volte6/gomud/characters"
+	"github.com/volte6/gomud/chatfilter"
+	"github.com/volte6/gomud/chatlog"
 	"github.com/volte6/gomud/colorpatterns"
 	"github.com/volte6/gomud/configs"
 	"github.com/volte6/gomud/connections"
+	"github.com/volte6/gomud/discord"
 	"github.com/volte6/gomud/events"
+	"github.com/volte6/gomud/factions"
 	"github.com/volte6/gomud/gametime"
 	"github.com/volte6/gomud/inputhandlers"
 	"github.com/volte6/gomud/items"
 	"github.com/volte6/gomud/keywords"
+	"github.com/volte6/gomud/loot"
 	"github.com/volte6/gomud/mobs"
+	"github.com/volte6/gomud/moons"
 	"github.com/volte6/gomud/mutators"
 	"github.com/volte6/gomud/pets"
 	"github.com/volte6/gomud/quests"
 	"github.com/volte6/gomud/races"
 	"github.com/volte6/gomud/rooms"
 	"github.com/volte6/gomud/scripting"
+	"github.com/volte6/gomud/socials"
 	"github.com/volte6/gomud/spells"
 	"github.com/volte6/gomud/templates"
 	"github.com/volte6/gomud/term"
@@ -126,9 +135,16 @@ func main() {
 	mobs.LoadDataFiles()
 	pets.LoadDataFiles()
 	quests.LoadDataFiles()
+	factions.LoadDataFiles()
+	achievements.LoadDataFiles()
+	loot.LoadDataFiles()
+	channels.LoadDataFiles()
+	chatfilter.LoadDataFiles()
 	templates.LoadAliases()
 	keywords.LoadAliases()
+	socials.LoadDataFile()
 	mutators.LoadDataFiles()
+	moons.LoadDataFiles()
 	gametime.SetToDay(-5)
 
 	for _, name := range colorpatterns.GetColorPatternNames() {
@@ -144,6 +160,16 @@ func main() {
 
 	scripting.Setup(int(c.ScriptLoadTimeoutMs), int(c.ScriptRoomTimeoutMs))
 
+	if c.DiscordWebhookUrl != `` {
+		discord.SetTransport(discord.NewWebhookTransport(string(c.DiscordWebhookUrl)))
+	}
+
+	if c.FolderChatLogs != `` {
+		if err := chatlog.Open(util.FilePath(string(c.FolderChatLogs)), int(c.ChatLogMaxAgeDays)); err != nil {
+			slog.Error("chatlog.Open()", "error", err)
+		}
+	}
+
 	//
 	slog.Info(`========================`)
 	//
@@ -227,6 +253,7 @@ func main() {
 	// Otherwise we end up getting flushed file saves incomplete.
 	wg.Wait()
 
+	chatlog.Close()
 }
 
 func handleTelnetConnection(connDetails *connections.ConnectionDetails, wg *sync.WaitGroup) {
@@ -9,6 +9,7 @@ import (
 	"github.com/volte6/gomud/events"
 	"github.com/volte6/gomud/items"
 	"github.com/volte6/gomud/mobs"
+	"github.com/volte6/gomud/quests"
 	"github.com/volte6/gomud/rooms"
 	"github.com/volte6/gomud/users"
 	"github.com/volte6/gomud/util"
@@ -76,6 +77,12 @@ func Give(rest string, mob *mobs.Mob, room *rooms.Room) (bool, error) {
 
 			}
 
+			events.AddToQueue(events.QuestObjective{
+				UserId:        targetUser.UserId,
+				ObjectiveType: string(quests.ObjectiveCollect),
+				ItemId:        giveItem.ItemId,
+			})
+
 			targetUser.SendText(
 				fmt.Sprintf(`<ansi fg="mobname">%s</ansi> gives you their <ansi fg="item">%s</ansi>.`, mob.Character.Name, giveItem.DisplayName()),
 			)
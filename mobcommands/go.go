@@ -83,6 +83,11 @@ func Go(rest string, mob *mobs.Mob, room *rooms.Room) (bool, error) {
 			return false, fmt.Errorf(`room %d not found`, goRoomId)
 		}
 
+		// Mobs stay out of player homes
+		if destRoom.IsHome() {
+			return true, nil
+		}
+
 		// Grab the exit in the target room that leads to this room (if any)
 		enterFromExit := destRoom.FindExitTo(room.RoomId)
 
@@ -56,7 +56,7 @@ func LookForTrouble(rest string, mob *mobs.Mob, room *rooms.Room) (bool, error)
 				entries += party.ChanceToBeTargetted(playerId)
 			}
 
-			if mob.Hostile { // Does it always attack players?
+			if mob.IsHostileToward(user.Character) { // Does it always attack this player?
 
 				allPotentialTargets = append(allPotentialTargets, playerId)
 
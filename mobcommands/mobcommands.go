@@ -9,6 +9,7 @@ import (
 	"github.com/volte6/gomud/keywords"
 	"github.com/volte6/gomud/mobs"
 	"github.com/volte6/gomud/rooms"
+	"github.com/volte6/gomud/socials"
 	"github.com/volte6/gomud/util"
 )
 
@@ -120,6 +121,12 @@ func TryCommand(cmd string, rest string, mobId int) (bool, error) {
 		return handled, err
 
 	}
+
+	// Socials, such as "smile" or "bow bobbin"
+	if social := socials.Get(cmd); social != nil {
+		return doSocial(social, rest, mob, room)
+	}
+
 	// Try moving if they aren't disabled
 	if !mobDisabled {
 		start := time.Now()
@@ -134,10 +141,6 @@ func TryCommand(cmd string, rest string, mobId int) (bool, error) {
 		}
 
 	}
-	if emoteText, ok := emoteAliases[cmd]; ok {
-		handled, err := Emote(emoteText, mob, room)
-		return handled, err
-	}
 
 	return false, nil
 }
@@ -0,0 +1,67 @@
+package mobcommands
+
+import (
+	"fmt"
+
+	"github.com/volte6/gomud/mobs"
+	"github.com/volte6/gomud/rooms"
+	"github.com/volte6/gomud/socials"
+	"github.com/volte6/gomud/users"
+)
+
+// Does a social such as smile or bow, to nobody in particular or to someone in the room.
+func doSocial(social *socials.Social, rest string, mob *mobs.Mob, room *rooms.Room) (bool, error) {
+
+	// Don't bother if no players are present
+	if room.PlayerCt() < 1 {
+		return true, nil
+	}
+
+	sourceName := fmt.Sprintf(`<ansi fg="mobname">%s</ansi>`, mob.Character.Name)
+
+	if rest == `` || social.Target == nil {
+		room.SendText(socialText(social.NoTarget.Room, sourceName, ``))
+		return true, nil
+	}
+
+	playerId, mobInstanceId := room.FindByName(rest)
+
+	if playerId > 0 {
+
+		if targetUser := users.GetByUserId(playerId); targetUser != nil {
+
+			targetName := fmt.Sprintf(`<ansi fg="username">%s</ansi>`, targetUser.Character.Name)
+
+			targetUser.SendText(socialText(social.Target.Target, sourceName, targetName))
+			room.SendText(socialText(social.Target.Room, sourceName, targetName), targetUser.UserId)
+
+			return true, nil
+		}
+	}
+
+	if mobInstanceId == mob.InstanceId {
+		room.SendText(socialText(social.Self.Room, sourceName, ``))
+		return true, nil
+	}
+
+	if mobInstanceId > 0 {
+
+		if targetMob := mobs.GetInstance(mobInstanceId); targetMob != nil {
+
+			targetName := fmt.Sprintf(`<ansi fg="mobname">%s</ansi>`, targetMob.Character.Name)
+
+			room.SendText(socialText(social.Target.Room, sourceName, targetName))
+
+			return true, nil
+		}
+	}
+
+	// Nobody by that name, so just do it
+	room.SendText(socialText(social.NoTarget.Room, sourceName, ``))
+
+	return true, nil
+}
+
+func socialText(msg string, sourceName string, targetName string) string {
+	return `<ansi fg="20">` + socials.Format(msg, sourceName, targetName) + `</ansi>`
+}
@@ -5,10 +5,15 @@ import (
 	"log/slog"
 	"math"
 
+	"github.com/volte6/gomud/achievements"
 	"github.com/volte6/gomud/buffs"
 	"github.com/volte6/gomud/combat"
+	"github.com/volte6/gomud/events"
+	"github.com/volte6/gomud/factions"
+	"github.com/volte6/gomud/loot"
 	"github.com/volte6/gomud/mobs"
 	"github.com/volte6/gomud/parties"
+	"github.com/volte6/gomud/quests"
 	"github.com/volte6/gomud/rooms"
 	"github.com/volte6/gomud/scripting"
 	"github.com/volte6/gomud/skills"
@@ -108,6 +113,19 @@ func Suicide(rest string, mob *mobs.Mob, room *rooms.Room) (bool, error) {
 
 					if mob.Character.Zone != `Training` { // Don't track any kills in the training zone
 						user.Character.KD.AddMobKill(int(mob.MobId))
+
+						adjustFactionReputation(user, mob)
+
+						events.AddToQueue(events.QuestObjective{
+							UserId:        user.UserId,
+							ObjectiveType: string(quests.ObjectiveKill),
+							MobId:         int(mob.MobId),
+						})
+
+						events.AddToQueue(events.Achievement{
+							UserId:       user.UserId,
+							CriteriaType: string(achievements.CriteriaKills),
+						})
 					}
 
 					xpScaler := 1.0
@@ -216,6 +234,22 @@ func Suicide(rest string, mob *mobs.Mob, room *rooms.Room) (bool, error) {
 
 						if mob.Character.Zone != `Training` { // Don't track any kills in the training zone
 							user.Character.KD.AddMobKill(int(mob.MobId))
+
+							// Only members who were there get credit toward their quests
+							if user.Character.RoomId == mob.Character.RoomId {
+								events.AddToQueue(events.QuestObjective{
+									UserId:        user.UserId,
+									ObjectiveType: string(quests.ObjectiveKill),
+									MobId:         int(mob.MobId),
+								})
+
+								events.AddToQueue(events.Achievement{
+									UserId:       user.UserId,
+									CriteriaType: string(achievements.CriteriaKills),
+								})
+
+								adjustFactionReputation(user, mob)
+							}
 						}
 
 						grantXP, xpScale := user.Character.GrantXP(xpSplit)
@@ -311,10 +345,47 @@ func Suicide(rest string, mob *mobs.Mob, room *rooms.Room) (bool, error) {
 			room.AddItem(item, false)
 		}
 
+		// Gold players gave the mob doesn't count toward what its killers earned
+		earnedGold := 0
+
 		if mob.Character.Gold > 0 {
 			msg := fmt.Sprintf(`<ansi fg="yellow-bold">%d gold</ansi> drops to the ground.`, mob.Character.Gold)
 			room.SendText(msg)
 			room.Gold += mob.Character.Gold
+
+			earnedGold = mob.Character.Gold
+			if mobSpec := mobs.GetMobSpec(mob.MobId); mobSpec != nil && mobSpec.Character.Gold < earnedGold {
+				earnedGold = mobSpec.Character.Gold
+			}
+		}
+
+		if mob.LootTable != `` {
+
+			drop := loot.Roll(mob.LootTable, mob.Character.Level)
+
+			for _, item := range drop.Items {
+				msg := fmt.Sprintf(`<ansi fg="item">%s</ansi> drops to the ground.`, item.DisplayName())
+				room.SendText(msg)
+				room.AddItem(item, false)
+			}
+
+			if drop.Gold > 0 {
+				msg := fmt.Sprintf(`<ansi fg="yellow-bold">%d gold</ansi> drops to the ground.`, drop.Gold)
+				room.SendText(msg)
+				room.Gold += drop.Gold
+
+				earnedGold += drop.Gold
+			}
+		}
+
+		// The gold is credited to the killers now, since picking it up off the ground doesn't count
+		if earnedGold > 0 && len(mob.DamageTaken) > 0 && mob.Character.Zone != `Training` {
+			goldShare := earnedGold / len(mob.DamageTaken)
+			for uId := range mob.DamageTaken {
+				if user := users.GetByUserId(uId); user != nil {
+					user.AddGoldEarned(goldShare)
+				}
+			}
 		}
 
 	}
@@ -332,3 +403,13 @@ func Suicide(rest string, mob *mobs.Mob, room *rooms.Room) (bool, error) {
 
 	return true, nil
 }
+
+// Killing a member of a faction hurts the killer's reputation with it, and helps with its enemies
+func adjustFactionReputation(user *users.UserRecord, mob *mobs.Mob) {
+	for _, f := range factions.GetFactionsForGroups(mob.Groups) {
+		user.AdjustReputation(f.FactionId, -f.KillPenalty)
+		for _, enemyId := range f.Enemies {
+			user.AdjustReputation(enemyId, f.KillPenalty/2)
+		}
+	}
+}
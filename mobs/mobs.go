@@ -57,6 +57,8 @@ type Mob struct {
 	MobId           MobId
 	Zone            string      `yaml:"zone,omitempty"`
 	ItemDropChance  int         // chance in 100
+	LootTable       string      `yaml:"loottable,omitempty"`     // Loot table rolled at the mob's level when killed
+	Repairer        bool        `yaml:"repairer,omitempty"`      // Will repair worn out items for a fee
 	ActivityLevel   int         `yaml:"activitylevel,omitempty"` // 1 - 10%, 10 = 100%
 	InstanceId      int         `yaml:"-"`
 	HomeRoomId      int         `yaml:"-"`
@@ -380,6 +382,23 @@ func (r *Mob) HatesRace(raceName string) bool {
 	return false
 }
 
+// Whether the mob attacks a character on sight.
+// Friendly standing with the mob's faction keeps usually hostile mobs at bay,
+// while hated characters are attacked by every member of the faction.
+func (r *Mob) IsHostileToward(c *characters.Character) bool {
+
+	standing, ok := c.GetFactionStanding(r.Groups)
+	if !ok {
+		return r.Hostile
+	}
+
+	if r.Hostile {
+		return !standing.IsFriendly()
+	}
+
+	return standing.IsHated()
+}
+
 func (r *Mob) HatesAlignment(otherAlignment int8) bool {
 
 	// If either are neutral, no hatred
@@ -1,5 +1,183 @@
 package moons
 
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/volte6/gomud/fileloader"
+	"github.com/volte6/gomud/gametime"
+	"github.com/volte6/gomud/statmods"
+)
+
 const (
 	MoonTemplatePath = "_datafiles/templates/moons"
+
+	// How many distinct phases a moon has. These line up with the numbered templates.
+	PhaseCount = 8
 )
+
+// Phase names, in order. The index of each name is the phase number (and template number)
+var (
+	PhaseNames = []string{
+		`new`,
+		`waxing-crescent`,
+		`first-quarter`,
+		`waxing-gibbous`,
+		`full`,
+		`waning-gibbous`,
+		`third-quarter`,
+		`waning-crescent`,
+	}
+
+	allMoons          = map[string]*MoonSpec{}
+	moonDataFilesPath = "_datafiles/moons"
+)
+
+type PhaseEffect struct {
+	StatMods statmods.StatMods `yaml:"statmods,omitempty"` // stat mods applied to every character while the moon is in this phase
+}
+
+type MoonSpec struct {
+	MoonId    string                 `yaml:"moonid"`              // Unique id of the moon, should match the template folder name ("noctherion")
+	Name      string                 `yaml:"name"`                // Display name of the moon
+	CycleDays int                    `yaml:"cycledays"`           // How many game days it takes to go through all phases
+	DayOffset int                    `yaml:"dayoffset,omitempty"` // Shifts the cycle so that moons don't all line up
+	Phases    map[string]PhaseEffect `yaml:"phases,omitempty"`    // phase name => effects during that phase
+}
+
+func (m *MoonSpec) Id() string {
+	return m.MoonId
+}
+
+func (m *MoonSpec) Filepath() string {
+	return fmt.Sprintf("%s.yaml", m.MoonId)
+}
+
+func (m *MoonSpec) Validate() error {
+
+	m.MoonId = strings.ToLower(m.MoonId)
+
+	if m.Name == `` {
+		m.Name = m.MoonId
+	}
+
+	if m.CycleDays < 1 {
+		m.CycleDays = PhaseCount // default
+	}
+
+	for phaseName := range m.Phases {
+		if PhaseNumber(phaseName) < 0 {
+			return fmt.Errorf(`moon %s has unknown phase: %s`, m.MoonId, phaseName)
+		}
+	}
+
+	return nil
+}
+
+// Returns the phase number (0-7) for a given game date
+func (m *MoonSpec) GetPhase(gd gametime.GameDate) int {
+	totalDays := (gd.Year-1)*365 + gd.Day + m.DayOffset
+	if totalDays < 0 {
+		totalDays = 0
+	}
+	dayOfCycle := totalDays % m.CycleDays
+	return (dayOfCycle * PhaseCount) / m.CycleDays
+}
+
+// Returns the phase name for a given game date
+func (m *MoonSpec) GetPhaseName(gd gametime.GameDate) string {
+	return PhaseNames[m.GetPhase(gd)]
+}
+
+func (m *MoonSpec) StatMod(statName string, gd gametime.GameDate) int {
+	if effect, ok := m.Phases[m.GetPhaseName(gd)]; ok {
+		return effect.StatMods.Get(statName)
+	}
+	return 0
+}
+
+// Converts a phase name into a phase number
+// Returns -1 if not found
+func PhaseNumber(phaseName string) int {
+	phaseName = strings.ToLower(strings.ReplaceAll(phaseName, ` `, `-`))
+	for i, name := range PhaseNames {
+		if name == phaseName {
+			return i
+		}
+	}
+	return -1
+}
+
+func GetMoon(moonId string) *MoonSpec {
+	if m, ok := allMoons[strings.ToLower(moonId)]; ok {
+		return m
+	}
+	return nil
+}
+
+// Returns all moon id's in a consistent order
+func GetMoonIds() []string {
+	ids := []string{}
+	for id := range allMoons {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Returns the current phase name of a moon, or an empty string if the moon doesn't exist
+func GetPhaseName(moonId string) string {
+	if m := GetMoon(moonId); m != nil {
+		return m.GetPhaseName(gametime.GetDate())
+	}
+	return ``
+}
+
+// Checks a condition such as "noctherion:full" or "arcanix:waxing-crescent"
+// A moon id alone ("noctherion") is treated as "noctherion:full"
+func IsPhase(condition string) bool {
+
+	moonId, phaseName, found := strings.Cut(strings.ToLower(condition), `:`)
+	if !found {
+		phaseName = `full`
+	}
+
+	m := GetMoon(moonId)
+	if m == nil {
+		return false
+	}
+
+	return m.GetPhaseName(gametime.GetDate()) == strings.ReplaceAll(phaseName, ` `, `-`)
+}
+
+// Sums up all of the stat mods provided by current moon phases
+func StatMod(statName string) int {
+
+	if len(allMoons) == 0 {
+		return 0
+	}
+
+	gd := gametime.GetDate()
+
+	total := 0
+	for _, m := range allMoons {
+		total += m.StatMod(statName, gd)
+	}
+	return total
+}
+
+func LoadDataFiles() {
+
+	start := time.Now()
+
+	var err error
+	allMoons, err = fileloader.LoadAllFlatFiles[string, *MoonSpec](moonDataFilesPath)
+	if err != nil {
+		panic(err)
+	}
+
+	slog.Info("moons.LoadDataFiles()", "loadedCount", len(allMoons), "Time Taken", time.Since(start))
+}
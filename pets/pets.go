@@ -26,6 +26,12 @@ type Pet struct {
 	BuffIds       []int             `yaml:"buffids,omitempty"`       // Permabuffs this pet affords the player
 	Capacity      int               `yaml:"capacity,omitempty"`      // How many items this mob can carry
 	Items         []items.Item      `yaml:"items,omitempty"`         // Items held by this pet
+	Mountable     bool              `yaml:"mountable,omitempty"`     // Can this pet be ridden?
+	Mounted       bool              `yaml:"mounted,omitempty"`       // Is the owner currently riding this pet?
+	MoveDiscount  int               `yaml:"movediscount,omitempty"`  // Percent movement cost is reduced by while ridden
+	CarryBonus    int               `yaml:"carrybonus,omitempty"`    // Extra carry capacity the rider gets while ridden
+	Health        int               `yaml:"health,omitempty"`        // Current health of a mount
+	HealthMax     int               `yaml:"healthmax,omitempty"`     // How much damage a mount can take before it dies. 0 = can't be hurt
 }
 
 var (
@@ -42,6 +48,21 @@ func (p *Pet) Exists() bool {
 	return p.Type != ``
 }
 
+// Whether the owner is currently riding this pet
+func (p *Pet) IsRidden() bool {
+	return p.Exists() && p.Mountable && p.Mounted
+}
+
+// Applies damage to a ridden mount.
+// Returns true if the mount died from the damage.
+func (p *Pet) TakeDamage(amount int) bool {
+	if !p.IsRidden() || p.HealthMax < 1 || amount < 1 {
+		return false
+	}
+	p.Health -= amount
+	return p.Health <= 0
+}
+
 func (p *Pet) DisplayName() string {
 
 	name := p.Name
@@ -167,6 +188,14 @@ func (p *Pet) Validate() error {
 		p.Items = []items.Item{}
 	}
 
+	if !p.Mountable {
+		p.Mounted = false
+	}
+
+	if p.HealthMax > 0 && (p.Health <= 0 || p.Health > p.HealthMax) {
+		p.Health = p.HealthMax
+	}
+
 	p.Damage.InitDiceRoll(p.Damage.DiceRoll)
 	p.Damage.FormatDiceRoll()
 
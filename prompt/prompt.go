@@ -38,6 +38,11 @@ type Question struct {
 	Flags           int      // Mask reply etc
 }
 
+const (
+	FlagMultiLine = 1 << iota // Answered with the line editor rather than a single line
+	FlagJoinLines             // The lines written in the editor are joined into a single paragraph
+)
+
 type Prompt struct {
 	Command   string      // Where does it call when complete?
 	Rest      string      // What is the 'rest' of the command
@@ -82,6 +87,26 @@ func (p *Prompt) Ask(question string, responseOptions []string, defaultOption ..
 	return q
 }
 
+// Like Ask, but answered with the line editor, so the answer can run over many lines.
+// The editor starts out holding defaultText. Finishing it empty (or aborting) gives an empty response.
+func (p *Prompt) AskMultiLine(question string, defaultText string) *Question {
+
+	q := p.Ask(question, []string{}, defaultText)
+	q.Flags |= FlagMultiLine
+
+	return q
+}
+
+// Like AskMultiLine, but the lines are joined into a single paragraph when finished.
+// For text that is always wrapped to fit wherever it's shown, such as descriptions.
+func (p *Prompt) AskParagraph(question string, defaultText string) *Question {
+
+	q := p.AskMultiLine(question, defaultText)
+	q.Flags |= FlagJoinLines
+
+	return q
+}
+
 // Returns the next pending question.
 func (p *Prompt) GetNextQuestion() *Question {
 
@@ -103,7 +128,7 @@ func (q *Question) Answer(answer string) {
 	// If an empty string, failover to default (if any)
 	// Otherwise, just abort and wait for a valid response
 	answer = strings.TrimSpace(answer)
-	if len(answer) == 0 {
+	if len(answer) == 0 && !q.IsMultiLine() {
 		if q.DefaultResponse == `` {
 			return
 		}
@@ -157,12 +182,30 @@ func (q *Question) Answer(answer string) {
 
 func (q *Question) RejectResponse() {
 
+	// Let them fix what they wrote, rather than starting over
+	if q.IsMultiLine() {
+		q.DefaultResponse = q.Response
+	}
+
 	q.Response = `` // Clear the response
 	q.Done = false  // Mark as not done
 }
 
+func (q *Question) IsMultiLine() bool {
+	return q.Flags&FlagMultiLine != 0
+}
+
+func (q *Question) JoinsLines() bool {
+	return q.Flags&FlagJoinLines != 0
+}
+
 func (q *Question) String() string {
 
+	// The line editor shows the question when it starts, so each line just gets a short prompt
+	if q.IsMultiLine() {
+		return `<ansi fg="black-bold">]</ansi> `
+	}
+
 	ret := strings.Builder{}
 	ret.WriteString(`<ansi fg="black-bold">.:</ansi> `) // Prompt prefix
 	ret.WriteString(`<ansi fg="yellow-bold">`)
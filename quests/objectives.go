@@ -0,0 +1,111 @@
+package quests
+
+import "fmt"
+
+type ObjectiveType string
+
+const (
+	ObjectiveKill    ObjectiveType = `kill`    // Kill Quantity of MobId
+	ObjectiveCollect ObjectiveType = `collect` // Carry Quantity of ItemId
+	ObjectiveVisit   ObjectiveType = `visit`   // Enter RoomId
+	ObjectiveTalk    ObjectiveType = `talk`    // Ask MobId about anything
+	ObjectiveDeliver ObjectiveType = `deliver` // Give Quantity of ItemId to MobId
+)
+
+// Something a player must do to finish a step of a quest.
+// When every objective of a step is met, the player moves on to the next step automatically.
+// Scripts can still move players along with GiveQuest(), objectives or not.
+type QuestObjective struct {
+	Type        ObjectiveType
+	MobId       int    // Mob to kill, talk to or deliver to
+	ItemId      int    // Item to collect or deliver
+	RoomId      int    // Room to visit
+	Quantity    int    // How many times. Defaults to 1
+	Description string // Shown next to the progress counter, such as "Rats killed" (optional)
+}
+
+func (o *QuestObjective) Validate() error {
+
+	switch o.Type {
+	case ObjectiveKill, ObjectiveTalk:
+		if o.MobId == 0 {
+			return fmt.Errorf(`%s objective requires a mobid`, o.Type)
+		}
+	case ObjectiveCollect:
+		if o.ItemId == 0 {
+			return fmt.Errorf(`%s objective requires an itemid`, o.Type)
+		}
+	case ObjectiveVisit:
+		if o.RoomId == 0 {
+			return fmt.Errorf(`%s objective requires a roomid`, o.Type)
+		}
+	case ObjectiveDeliver:
+		if o.MobId == 0 || o.ItemId == 0 {
+			return fmt.Errorf(`%s objective requires a mobid and an itemid`, o.Type)
+		}
+	default:
+		return fmt.Errorf(`unknown objective type: %s`, o.Type)
+	}
+
+	if o.Quantity < 1 {
+		o.Quantity = 1 // default
+	}
+
+	// Visiting or talking to someone more than once doesn't make sense
+	if o.Type == ObjectiveVisit || o.Type == ObjectiveTalk {
+		o.Quantity = 1
+	}
+
+	return nil
+}
+
+// Whether something a player did counts toward this objective.
+func (o *QuestObjective) Matches(objType ObjectiveType, mobId int, itemId int, roomId int) bool {
+
+	if o.Type != objType {
+		return false
+	}
+
+	switch o.Type {
+	case ObjectiveKill, ObjectiveTalk:
+		return o.MobId == mobId
+	case ObjectiveCollect:
+		return o.ItemId == itemId
+	case ObjectiveVisit:
+		return o.RoomId == roomId
+	case ObjectiveDeliver:
+		return o.MobId == mobId && o.ItemId == itemId
+	}
+
+	return false
+}
+
+// Returns the step with the given id, or nil
+func (r *Quest) GetStep(stepId string) *QuestStep {
+	for i := range r.Steps {
+		if r.Steps[i].Id == stepId {
+			return &r.Steps[i]
+		}
+	}
+	return nil
+}
+
+// Returns the branch of a step with the given id, or nil
+func (s *QuestStep) GetBranch(branchId string) *QuestBranch {
+	for i := range s.Branches {
+		if s.Branches[i].Id == branchId {
+			return &s.Branches[i]
+		}
+	}
+	return nil
+}
+
+// Returns the id of the step after the given step, or an empty string if it's the last one
+func (r *Quest) NextStepId(stepId string) string {
+	for i := range r.Steps {
+		if r.Steps[i].Id == stepId && i+1 < len(r.Steps) {
+			return r.Steps[i+1].Id
+		}
+	}
+	return ``
+}
@@ -21,15 +21,17 @@ var (
 )
 
 type QuestReward struct {
-	QuestId       string // new questId to give ( {id}-{step} format )
-	Gold          int    // zero or more gold to give.
-	ItemId        int    // itemId to give
-	BuffId        int    // buffId to apply
-	Experience    int    // experience to give
-	SkillInfo     string // skill to give, format: skillId:skillLevel such as "map:1"
-	PlayerMessage string // string to display to player
-	RoomMessage   string // string to display to room
-	RoomId        int    // roomId to move player to
+	QuestId       string         // new questId to give ( {id}-{step} format )
+	Gold          int            // zero or more gold to give.
+	ItemId        int            // itemId to give
+	BuffId        int            // buffId to apply
+	Experience    int            // experience to give
+	SkillInfo     string         // skill to give, format: skillId:skillLevel such as "map:1"
+	PlayerMessage string         // string to display to player
+	RoomMessage   string         // string to display to room
+	RoomId        int            // roomId to move player to
+	Reputation    map[string]int // reputation changes by faction id, such as "frostfang: 50"
+	LootTable     string         // loot table to roll at the player's level
 }
 
 type Quest struct {
@@ -39,19 +41,54 @@ type Quest struct {
 	Secret      bool        // Secret quests are useful for marking some progress without making it known to the player
 	Steps       []QuestStep // String identifiers for each step required to complete the quest
 	Rewards     QuestReward
+	Repeatable  string         // How long after completion until the quest can be done again, such as "1 day" or "1 week real" (optional)
+	MinLevel    int            // Minimum level to start the quest, however it's given (optional)
+	Requires    []int          // Quest ids that must be completed before starting this quest (optional)
+	Reputation  map[string]int // Minimum reputation by faction id required to start this quest (optional)
 }
 
 type QuestStep struct {
-	Id          string // A way to identify this step of the quest such as "start"
-	Description string // A description of the step
-	Hint        string // A hint to accomplish this step (optional)
+	Id           string           // A way to identify this step of the quest such as "start"
+	Description  string           // A description of the step
+	Hint         string           // A hint to accomplish this step (optional)
+	Objectives   []QuestObjective // Objectives that complete this step automatically (optional)
+	Branches     []QuestBranch    // Paths the story can take from this step. Only one can be chosen (optional)
+	BranchPrompt string           // Question asked so the player can choose a branch. If empty, scripts must choose
+}
+
+// One of several paths the story can take from a step of a quest
+type QuestBranch struct {
+	Id          string         // A way to identify this branch such as "thieves"
+	Description string         // Describes the choice, such as "Help the thieves escape"
+	QuestId     string         // Quest token given when this branch is chosen ( {id}-{step} format )
+	Excludes    []int          // Quest ids closed off for good once this branch is chosen
+	Alignment   int            // Change in alignment when this branch is chosen (optional)
+	Reputation  map[string]int // Change in reputation by faction id when this branch is chosen (optional)
 }
 
 func (r *Quest) Id() int {
 	return r.QuestId
 }
 
+func (r *Quest) IsRepeatable() bool {
+	return r.Repeatable != ``
+}
+
 func (r *Quest) Validate() error {
+
+	for i := range r.Steps {
+		for j := range r.Steps[i].Objectives {
+			if err := r.Steps[i].Objectives[j].Validate(); err != nil {
+				return fmt.Errorf(`quest %d step %s: %w`, r.QuestId, r.Steps[i].Id, err)
+			}
+		}
+		for _, branch := range r.Steps[i].Branches {
+			if branch.Id == `` {
+				return fmt.Errorf(`quest %d step %s: branch has no id`, r.QuestId, r.Steps[i].Id)
+			}
+		}
+	}
+
 	return nil
 }
 
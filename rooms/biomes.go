@@ -89,7 +89,7 @@ var (
 			name:           `Deep Water`,
 			symbol:         '≈',
 			description:    `Deep water is dangerous and usually requires some sort of assistance to cross.`,
-			requiredItemId: 20030,
+			requiredItemId: 28, // coracle
 		},
 		`forest`: {
 			name:        `Forest`,
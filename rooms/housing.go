@@ -0,0 +1,367 @@
+package rooms
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/volte6/gomud/configs"
+	"github.com/volte6/gomud/gametime"
+	"github.com/volte6/gomud/users"
+	"github.com/volte6/gomud/util"
+)
+
+const (
+	HomeExitPrefix     = `home-`
+	HomeChestName      = `chest`
+	homeDefaultBiome   = `house`
+	homeDefaultDesc    = `A modest, empty home. The walls are bare and the floorboards creak underfoot. It's waiting for someone to make it their own.`
+	homeTitleFormat    = `%s's Home`
+	homeExitToDistrict = `out`
+)
+
+var (
+	ErrNotHousingDistrict = errors.New(`not a housing district`)
+	ErrAlreadyOwnsHome    = errors.New(`already owns a home`)
+)
+
+// Player owned home details
+// Stored on the room itself, so it is saved and loaded along with the room.
+type HomeInfo struct {
+	OwnerUserId    int            `yaml:"owneruserid,omitempty"`    // 0 means the home is vacant
+	OwnerUsername  string         `yaml:"ownerusername,omitempty"`  // Used to collect rent while the owner is offline
+	OwnerName      string         `yaml:"ownername,omitempty"`      // Character name at the time of purchase
+	DistrictRoomId int            `yaml:"districtroomid"`           // The housing district room the home is attached to
+	DistrictExit   string         `yaml:"districtexit,omitempty"`   // Name of the exit in the district room that leads here
+	Guests         map[int]string `yaml:"guests,omitempty"`         // userId => character name of anyone else allowed in
+	RentDueRound   uint64         `yaml:"rentdueround,omitempty"`   // When the next rent payment will be collected
+	MissedPayments int            `yaml:"missedpayments,omitempty"` // How many rent payments in a row have been missed
+}
+
+type homeIndexEntry struct {
+	OwnerUserId    int
+	DistrictRoomId int
+	RentDueRound   uint64
+}
+
+func (h *HomeInfo) IsVacant() bool {
+	return h.OwnerUserId == 0
+}
+
+func (h *HomeInfo) IsOwner(userId int) bool {
+	return h.OwnerUserId != 0 && h.OwnerUserId == userId
+}
+
+func (h *HomeInfo) HasAccess(userId int) bool {
+	if h.IsOwner(userId) {
+		return true
+	}
+	_, ok := h.Guests[userId]
+	return ok
+}
+
+func (h *HomeInfo) AddGuest(userId int, name string) {
+	if h.Guests == nil {
+		h.Guests = make(map[int]string)
+	}
+	h.Guests[userId] = name
+}
+
+// Removes a guest by name and returns their userId, or 0 if not found
+func (h *HomeInfo) RemoveGuest(name string) int {
+	for userId, guestName := range h.Guests {
+		if strings.EqualFold(guestName, name) {
+			delete(h.Guests, userId)
+			return userId
+		}
+	}
+	return 0
+}
+
+// Returns guest names in alphabetical order
+func (h *HomeInfo) GuestNames() []string {
+	names := []string{}
+	for _, name := range h.Guests {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (r *Room) IsHome() bool {
+	return r.Home != nil && !r.Home.IsVacant()
+}
+
+// Whether a user can enter this room.
+// Only homes have restrictions, and admins can always enter.
+func (r *Room) CanEnter(user *users.UserRecord) bool {
+	if !r.IsHome() {
+		return true
+	}
+	if user.Permission == users.PermissionAdmin {
+		return true
+	}
+	return r.Home.HasAccess(user.UserId)
+}
+
+// Finds the roomId of a home owned by a user
+// Returns 0 if they don't own one.
+func GetHomeRoomId(userId int) int {
+	for roomId, entry := range roomManager.homes {
+		if entry.OwnerUserId == userId {
+			return roomId
+		}
+	}
+	return 0
+}
+
+// Creates a new home for a user, attached to a housing district room.
+// Vacant homes in the same district are reused before building a new room.
+func BuildHome(districtRoomId int, owner *users.UserRecord) (*Room, error) {
+
+	district := LoadRoom(districtRoomId)
+	if district == nil {
+		return nil, fmt.Errorf(`room %d not found`, districtRoomId)
+	}
+
+	if !district.IsHousingDistrict {
+		return nil, ErrNotHousingDistrict
+	}
+
+	if GetHomeRoomId(owner.UserId) > 0 {
+		return nil, ErrAlreadyOwnsHome
+	}
+
+	exitName := HomeExitPrefix + strings.ToLower(owner.Character.Name)
+	if _, ok := district.Exits[exitName]; ok {
+		return nil, fmt.Errorf(`exit %s already exists`, exitName)
+	}
+
+	var home *Room
+
+	// Look for a vacant home in this district
+	for roomId, entry := range roomManager.homes {
+		if entry.OwnerUserId != 0 || entry.DistrictRoomId != districtRoomId {
+			continue
+		}
+		if vacantRoom := LoadRoom(roomId); vacantRoom != nil {
+			home = vacantRoom
+			break
+		}
+	}
+
+	if home == nil {
+		newRoom, err := BuildRoom(districtRoomId, exitName)
+		if err != nil {
+			return nil, err
+		}
+		home = newRoom
+	} else {
+		district.Exits[exitName] = RoomExit{RoomId: home.RoomId}
+	}
+
+	home.Title = fmt.Sprintf(homeTitleFormat, owner.Character.Name)
+	home.Description = homeDefaultDesc
+	home.Biome = homeDefaultBiome
+	home.IsIndoors = true
+	home.MapSymbol = ``
+	home.SpawnInfo = []SpawnInfo{}
+	home.IdleMessages = []string{}
+	home.Exits = map[string]RoomExit{
+		homeExitToDistrict: {RoomId: districtRoomId},
+	}
+
+	if home.Containers == nil {
+		home.Containers = map[string]Container{}
+	}
+	if _, ok := home.Containers[HomeChestName]; !ok {
+		home.Containers[HomeChestName] = Container{}
+	}
+
+	home.Home = &HomeInfo{
+		OwnerUserId:    owner.UserId,
+		OwnerUsername:  owner.Username,
+		OwnerName:      owner.Character.Name,
+		DistrictRoomId: districtRoomId,
+		DistrictExit:   exitName,
+		RentDueRound:   gametime.GetDate().AddPeriod(string(configs.GetConfig().HousingRentPeriod)),
+	}
+
+	updateHomeIndex(home)
+
+	SaveRoom(*district)
+	SaveRoom(*home)
+
+	slog.Info("Home built", "roomId", home.RoomId, "owner", owner.Character.Name, "district", districtRoomId)
+
+	return home, nil
+}
+
+// Removes the owner from a home and returns everything left inside to them.
+// Items go to their item storage, gold goes to their bank.
+func EvictHome(roomId int) error {
+
+	home := LoadRoom(roomId)
+	if home == nil {
+		return fmt.Errorf(`room %d not found`, roomId)
+	}
+
+	if !home.IsHome() {
+		return fmt.Errorf(`room %d is not an owned home`, roomId)
+	}
+
+	h := home.Home
+
+	// Gather up everything left behind
+	returnedItems := append(home.Items, home.Stash...)
+	returnedGold := home.Gold
+	for name, container := range home.Containers {
+		returnedItems = append(returnedItems, container.Items...)
+		returnedGold += container.Gold
+		home.Containers[name] = Container{}
+	}
+	home.Items = nil
+	home.Stash = nil
+	home.Gold = 0
+
+	returnFunc := func(u *users.UserRecord) {
+		for _, itm := range returnedItems {
+			u.ItemStorage.AddItem(itm)
+		}
+		u.Character.Bank += returnedGold
+	}
+
+	if owner := users.GetByUserId(h.OwnerUserId); owner != nil {
+		returnFunc(owner)
+		owner.SendText(`<ansi fg="red">You no longer own your home.</ansi> Anything left inside has been moved to your item storage and bank.`)
+	} else if owner, err := users.LoadUser(h.OwnerUsername); err == nil {
+		returnFunc(owner)
+		owner.Inbox.Add(users.Message{
+			FromName: `Housing Office`,
+			Message:  `You no longer own your home. Anything left inside has been moved to your item storage and bank.`,
+		})
+		users.SaveUser(*owner)
+	}
+
+	// Anyone still inside gets put out on the street
+	for _, userId := range home.GetPlayers() {
+		if u := users.GetByUserId(userId); u != nil {
+			u.SendText(`The housing office has reclaimed this home. You are shown the door.`)
+			MoveToRoom(userId, h.DistrictRoomId)
+		}
+	}
+
+	if district := LoadRoom(h.DistrictRoomId); district != nil {
+		delete(district.Exits, h.DistrictExit)
+		SaveRoom(*district)
+	}
+
+	home.Title = `A Vacant Home`
+	home.Description = homeDefaultDesc
+	home.Home = &HomeInfo{
+		DistrictRoomId: h.DistrictRoomId,
+	}
+
+	updateHomeIndex(home)
+	SaveRoom(*home)
+
+	slog.Info("Home evicted", "roomId", home.RoomId, "owner", h.OwnerName)
+
+	return nil
+}
+
+// Checks all homes for rent that is due, and collects it from the owners bank.
+// Owners who miss too many payments in a row are evicted.
+func CollectRent() {
+
+	roundNow := util.GetRoundCount()
+
+	dueRoomIds := []int{}
+	for roomId, entry := range roomManager.homes {
+		if entry.OwnerUserId == 0 || entry.RentDueRound > roundNow {
+			continue
+		}
+		dueRoomIds = append(dueRoomIds, roomId)
+	}
+
+	if len(dueRoomIds) == 0 {
+		return
+	}
+
+	c := configs.GetConfig()
+	rent := int(c.HousingRent)
+
+	for _, roomId := range dueRoomIds {
+
+		home := LoadRoom(roomId)
+		if home == nil || !home.IsHome() {
+			delete(roomManager.homes, roomId)
+			continue
+		}
+
+		h := home.Home
+
+		paid := false
+		chargeFunc := func(u *users.UserRecord) {
+			if u.Character.Bank >= rent {
+				u.Character.Bank -= rent
+				paid = true
+			}
+		}
+
+		var msg string
+
+		owner := users.GetByUserId(h.OwnerUserId)
+		if owner != nil {
+			chargeFunc(owner)
+		} else if offlineOwner, err := users.LoadUser(h.OwnerUsername); err == nil {
+			chargeFunc(offlineOwner)
+			owner = offlineOwner
+		}
+
+		if paid {
+			h.MissedPayments = 0
+			msg = fmt.Sprintf(`Rent of <ansi fg="gold">%d gold</ansi> was collected from your bank for your home.`, rent)
+		} else {
+			h.MissedPayments++
+			msg = fmt.Sprintf(`You missed a rent payment of <ansi fg="gold">%d gold</ansi> for your home! Missed payments: <ansi fg="red">%d/%d</ansi>. Make sure your bank has enough gold.`, rent, h.MissedPayments, c.HousingMissedRentLimit)
+		}
+
+		h.RentDueRound = gametime.GetDate().AddPeriod(string(c.HousingRentPeriod))
+		updateHomeIndex(home)
+
+		if owner != nil {
+			if users.GetByUserId(owner.UserId) != nil {
+				owner.SendText(msg)
+			} else {
+				owner.Inbox.Add(users.Message{
+					FromName: `Housing Office`,
+					Message:  msg,
+				})
+				users.SaveUser(*owner)
+			}
+		}
+
+		if h.MissedPayments >= int(c.HousingMissedRentLimit) {
+			EvictHome(roomId)
+			continue
+		}
+
+		SaveRoom(*home)
+	}
+
+}
+
+func updateHomeIndex(r *Room) {
+	if r.Home == nil {
+		delete(roomManager.homes, r.RoomId)
+		return
+	}
+	roomManager.homes[r.RoomId] = homeIndexEntry{
+		OwnerUserId:    r.Home.OwnerUserId,
+		DistrictRoomId: r.Home.DistrictRoomId,
+		RentDueRound:   r.Home.RentDueRound,
+	}
+}
@@ -0,0 +1,35 @@
+package rooms
+
+import (
+	"math/rand"
+
+	"github.com/volte6/gomud/configs"
+	"github.com/volte6/gomud/gametime"
+)
+
+func (r *Room) IsQuestBoard() bool {
+	return len(r.QuestBoard) > 0
+}
+
+// Returns the quest ids the quest board in this room is offering today.
+// The selection changes each game day, and is the same for everyone.
+func (r *Room) GetQuestBoardOffers() []int {
+
+	offerCt := int(configs.GetConfig().QuestBoardOffers)
+
+	if len(r.QuestBoard) <= offerCt {
+		return append([]int{}, r.QuestBoard...)
+	}
+
+	gd := gametime.GetDate()
+	daySeed := int64(gd.Year)*10000 + int64(gd.Month)*100 + int64(gd.Day)
+
+	rng := rand.New(rand.NewSource(daySeed + int64(r.RoomId)))
+
+	offers := make([]int, 0, offerCt)
+	for _, idx := range rng.Perm(len(r.QuestBoard))[:offerCt] {
+		offers = append(offers, r.QuestBoard[idx])
+	}
+
+	return offers
+}
@@ -91,6 +91,34 @@ func GetDetails(r *Room, user *users.UserRecord) RoomTemplateDetails {
 		details.RoomAlerts = append(details.RoomAlerts, `      <ansi fg="yellow-bold">This is a character room!</ansi> Type <ansi fg="command">character</ansi> to interact.`)
 	}
 
+	if r.IsStable {
+		details.RoomAlerts = append(details.RoomAlerts, `        <ansi fg="yellow-bold">This is a stable!</ansi> Type <ansi fg="command">stable</ansi> to leave or collect a pet.`)
+	}
+
+	if r.IsPostOffice {
+		details.RoomAlerts = append(details.RoomAlerts, `     <ansi fg="yellow-bold">This is a post office!</ansi> Type <ansi fg="command">mail</ansi> to send and collect letters.`)
+	}
+
+	if r.IsQuestBoard() {
+		details.RoomAlerts = append(details.RoomAlerts, `   <ansi fg="yellow-bold">There is a quest board here!</ansi> Type <ansi fg="command">questboard</ansi> to see what's posted today.`)
+	}
+
+	if r.Board != `` {
+		details.RoomAlerts = append(details.RoomAlerts, `   <ansi fg="yellow-bold">There is a bulletin board here!</ansi> Type <ansi fg="command">board</ansi> to see what's been posted.`)
+	}
+
+	if r.IsArena {
+		details.RoomAlerts = append(details.RoomAlerts, `       <ansi fg="yellow-bold">This is an arena!</ansi> Type <ansi fg="command">duel [player]</ansi> to issue a challenge.`)
+	}
+
+	if r.IsHousingDistrict {
+		details.RoomAlerts = append(details.RoomAlerts, `  <ansi fg="yellow-bold">This is a housing district!</ansi> Type <ansi fg="command">house build</ansi> with a deed to move in.`)
+	}
+
+	if r.IsHome() && r.Home.IsOwner(user.UserId) {
+		details.RoomAlerts = append(details.RoomAlerts, `           <ansi fg="yellow-bold">This is your home!</ansi> Type <ansi fg="command">help house</ansi> to see what you can do.`)
+	}
+
 	if r.RoomId == -1 {
 		details.RoomAlerts = append(details.RoomAlerts, `<ansi fg="yellow-bold">Type <ansi fg="command">help races</ansi> to see a list of available races.</ansi>`+
 			"\n"+`      <ansi fg="yellow-bold">Type <ansi fg="command">start</ansi> to begin playing.</ansi>`)
@@ -11,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/volte6/gomud/boards"
 	"github.com/volte6/gomud/characters"
 	"github.com/volte6/gomud/colorpatterns"
 	"github.com/volte6/gomud/configs"
@@ -19,6 +20,7 @@ import (
 	"github.com/volte6/gomud/fileloader"
 	"github.com/volte6/gomud/mobs"
 	"github.com/volte6/gomud/mutators"
+	"github.com/volte6/gomud/quests"
 	"github.com/volte6/gomud/templates"
 	"github.com/volte6/gomud/term"
 	"github.com/volte6/gomud/users"
@@ -37,17 +39,21 @@ var (
 		roomsWithMobs:        make(map[int]int),
 		roomDescriptionCache: make(map[string]string),
 		roomIdToFileCache:    make(map[int]string),
+		homes:                make(map[int]homeIndexEntry),
+		vehicles:             make(map[int]vehicleIndexEntry),
 	}
 )
 
 type RoomManager struct {
 	rooms                map[int]*Room
-	zones                map[string]ZoneInfo // a map of zone name to room id
-	roomsWithUsers       map[int]int         // key is roomId to # players
-	roomsWithMobs        map[int]int         // key is roomId to # mobs
-	topRoomItems         []int               // list of the top room items
-	roomDescriptionCache map[string]string   // key is a hash, value is the description
-	roomIdToFileCache    map[int]string      // key is room id, value is the file path
+	zones                map[string]ZoneInfo       // a map of zone name to room id
+	roomsWithUsers       map[int]int               // key is roomId to # players
+	roomsWithMobs        map[int]int               // key is roomId to # mobs
+	topRoomItems         []int                     // list of the top room items
+	roomDescriptionCache map[string]string         // key is a hash, value is the description
+	roomIdToFileCache    map[int]string            // key is room id, value is the file path
+	homes                map[int]homeIndexEntry    // key is room id, value is a summary of the player owned home
+	vehicles             map[int]vehicleIndexEntry // key is room id, value is the route and where it was last seen
 }
 
 const (
@@ -330,6 +336,17 @@ func MoveToRoom(userId int, toRoomId int, isSpawn ...bool) error {
 	user.Character.Zone = newRoom.Zone
 	user.Character.RememberRoom(newRoom.RoomId) // Mark this room as remembered.
 
+	// Exploring a new zone might unlock achievements
+	if user.Character.VisitZone(newRoom.Zone) {
+		events.AddToQueue(events.Achievement{UserId: user.UserId})
+	}
+
+	events.AddToQueue(events.QuestObjective{
+		UserId:        user.UserId,
+		ObjectiveType: string(quests.ObjectiveVisit),
+		RoomId:        newRoom.RoomId,
+	})
+
 	roundNow := util.GetRoundCount()
 
 	if user.Character.Level < 5 && toRoomId > -1 {
@@ -494,6 +511,41 @@ func MoveToRoom(userId int, toRoomId int, isSpawn ...bool) error {
 			detailCt++
 			roomInfoStr.WriteString(`"storage"`)
 		}
+		if newRoom.IsStable {
+			if detailCt > 0 {
+				roomInfoStr.WriteString(`, `)
+			}
+			detailCt++
+			roomInfoStr.WriteString(`"stable"`)
+		}
+		if newRoom.IsPostOffice {
+			if detailCt > 0 {
+				roomInfoStr.WriteString(`, `)
+			}
+			detailCt++
+			roomInfoStr.WriteString(`"post office"`)
+		}
+		if newRoom.IsQuestBoard() {
+			if detailCt > 0 {
+				roomInfoStr.WriteString(`, `)
+			}
+			detailCt++
+			roomInfoStr.WriteString(`"questboard"`)
+		}
+		if newRoom.Board != `` {
+			if detailCt > 0 {
+				roomInfoStr.WriteString(`, `)
+			}
+			detailCt++
+			roomInfoStr.WriteString(`"bulletin board"`)
+		}
+		if newRoom.IsArena {
+			if detailCt > 0 {
+				roomInfoStr.WriteString(`, `)
+			}
+			detailCt++
+			roomInfoStr.WriteString(`"arena"`)
+		}
 		roomInfoStr.WriteString(`]`)
 		// end details
 
@@ -529,6 +581,11 @@ func GetRoomWithMostItems(skipRecentlyVisited bool, minimumItemCt int, minimumGo
 			continue
 		}
 
+		// Player homes are off limits
+		if cRoom.IsHome() {
+			continue
+		}
+
 		iCt := len(cRoom.Items)
 
 		if iCt < minimumItemCt && cRoom.Gold < minimumGoldCt {
@@ -651,7 +708,8 @@ func loadAllRoomZones() error {
 	for _, loadedRoom := range loadedRooms {
 
 		// Room 75 is the death/shadow realm and gets a pass
-		if loadedRoom.RoomId == 75 {
+		// Vehicles are only reached through temporary exits
+		if loadedRoom.RoomId == 75 || loadedRoom.Vehicle != nil {
 			continue
 		}
 
@@ -686,6 +744,16 @@ func loadAllRoomZones() error {
 		// Cache the file path for every roomId
 		roomManager.roomIdToFileCache[loadedRoom.RoomId] = loadedRoom.Filepath()
 
+		// Keep track of player homes so rent can be collected without loading every room
+		if loadedRoom.Home != nil {
+			updateHomeIndex(loadedRoom)
+		}
+
+		// Keep track of vehicles so they can travel their routes without being loaded
+		if loadedRoom.Vehicle != nil {
+			updateVehicleIndex(loadedRoom)
+		}
+
 		// Update the zone info cache
 		if _, ok := roomManager.zones[loadedRoom.Zone]; !ok {
 			roomManager.zones[loadedRoom.Zone] = ZoneInfo{
@@ -749,11 +817,26 @@ func removeRoomFromMemory(r *Room) {
 	SaveRoom(*room)
 	delete(roomManager.rooms, r.RoomId)
 
+	// Posts are saved as they're made, so the board only needs to stay in memory while a room is using it
+	if room.Board != `` && !boardInUse(room.Board) {
+		boards.Unload(room.Board)
+	}
+
 	afterCt := len(roomManager.rooms)
 
 	slog.Info("Removing from memory", "RoomId", r.RoomId, "Title", r.Title, "beforeCt", beforeCt, "afterCt", afterCt)
 }
 
+// Whether any room still in memory has this bulletin board
+func boardInUse(boardId string) bool {
+	for _, r := range roomManager.rooms {
+		if r.Board == boardId {
+			return true
+		}
+	}
+	return false
+}
+
 // Loads a room from disk and stores in memory
 func addRoomToMemory(r *Room) {
 
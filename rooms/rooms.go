@@ -13,7 +13,9 @@ import (
 	"github.com/volte6/gomud/events"
 	"github.com/volte6/gomud/gametime"
 	"github.com/volte6/gomud/items"
+	"github.com/volte6/gomud/loot"
 	"github.com/volte6/gomud/mobs"
+	"github.com/volte6/gomud/moons"
 	"github.com/volte6/gomud/mutators"
 	"github.com/volte6/gomud/users"
 	"github.com/volte6/gomud/util"
@@ -61,12 +63,21 @@ const (
 
 type Room struct {
 	//mutex
-	RoomId            int        // a unique numeric index of the room. Also the filename.
-	Zone              string     // zone is a way to partition rooms into groups. Also into folders.
-	ZoneConfig        ZoneConfig `yaml:"zoneconfig,omitempty"`      // If non-null is a root room.
-	IsBank            bool       `yaml:"isbank,omitempty"`          // Is this a bank room? If so, players can deposit/withdraw gold here.
-	IsStorage         bool       `yaml:"isstorage,omitempty"`       // Is this a storage room? If so, players can add/remove objects here.
-	IsCharacterRoom   bool       `yaml:"ischaracterroom,omitempty"` // Is this a room where characters can create new characters to swap between them?
+	RoomId            int          // a unique numeric index of the room. Also the filename.
+	Zone              string       // zone is a way to partition rooms into groups. Also into folders.
+	ZoneConfig        ZoneConfig   `yaml:"zoneconfig,omitempty"`        // If non-null is a root room.
+	IsBank            bool         `yaml:"isbank,omitempty"`            // Is this a bank room? If so, players can deposit/withdraw gold here.
+	IsStorage         bool         `yaml:"isstorage,omitempty"`         // Is this a storage room? If so, players can add/remove objects here.
+	IsCharacterRoom   bool         `yaml:"ischaracterroom,omitempty"`   // Is this a room where characters can create new characters to swap between them?
+	IsHousingDistrict bool         `yaml:"ishousingdistrict,omitempty"` // Is this a housing district? If so, players can use a deed here to build a home.
+	IsStable          bool         `yaml:"isstable,omitempty"`          // Is this a stable? If so, players can leave and collect their pets here.
+	IsPostOffice      bool         `yaml:"ispostoffice,omitempty"`      // Is this a post office? If so, players can send mail here.
+	QuestBoard        []int        `yaml:"questboard,omitempty"`        // Quest ids offered by a quest board in this room. A few are picked each day.
+	Board             string       `yaml:"board,omitempty"`             // BoardId of a bulletin board in this room, if any
+	IsArena           bool         `yaml:"isarena,omitempty"`           // Is this an arena? If so, PvP is always allowed and nobody really dies.
+	IsIndoors         bool         `yaml:"isindoors,omitempty"`         // Is this room indoors? If so, mounts can't be ridden into it.
+	Home              *HomeInfo    `yaml:"home,omitempty"`              // If set, this room is a player owned home.
+	Vehicle           *VehicleInfo `yaml:"vehicle,omitempty"`           // If set, this room travels along a route (such as a boat)
 	Title             string
 	Description       string
 	MapSymbol         string               `yaml:"mapsymbol,omitempty"`  // The symbol to use when generating a map of the zone
@@ -118,12 +129,14 @@ func NewRoom(zone string) *Room {
 	return r
 }
 
-func (r *Room) SendTextCommunication(txt string, excludeUserIds ...int) {
+// Sends something a player said or did to the room. The player sending it doesn't receive it.
+func (r *Room) SendTextCommunication(txt string, fromUserId int, excludeUserIds ...int) {
 
 	events.AddToQueue(events.Message{
 		RoomId:          r.RoomId,
+		FromUserId:      fromUserId,
 		Text:            txt + "\n",
-		ExcludeUserIds:  excludeUserIds,
+		ExcludeUserIds:  append(excludeUserIds, fromUserId),
 		IsQuiet:         false,
 		IsCommunication: true,
 	})
@@ -366,6 +379,11 @@ func (r *Room) Prepare(checkAdjacentRooms bool) {
 			}
 		}
 
+		// Some spawns only happen under certain moons
+		if spawnInfo.MoonPhase != `` && !moons.IsPhase(spawnInfo.MoonPhase) {
+			continue
+		}
+
 		//
 		// At this point we are good to attempt respawns
 		//
@@ -450,11 +468,20 @@ func (r *Room) Prepare(checkAdjacentRooms bool) {
 			continue
 		}
 
-		if spawnInfo.ItemId > 0 || spawnInfo.Gold > 0 {
+		if spawnInfo.ItemId > 0 || spawnInfo.Gold > 0 || spawnInfo.LootTable != `` {
 
 			// If no container specified, or the container specified exists, then spawn the item
 			if spawnInfo.Container == `` {
 
+				// Don't pile loot up on a floor nobody has looted
+				lootTaken := spawnInfo.LootGold == 0 || r.Gold < spawnInfo.LootGold
+				for _, itemId := range spawnInfo.LootItemIds {
+					if _, onFloor := r.FindOnFloor(fmt.Sprintf(`!%d`, itemId), false); onFloor {
+						lootTaken = false
+						break
+					}
+				}
+
 				if _, alreadyExists := r.FindOnFloor(fmt.Sprintf(`!%d`, spawnInfo.ItemId), false); !alreadyExists {
 
 					if item := items.New(spawnInfo.ItemId); item.ItemId != 0 {
@@ -467,6 +494,18 @@ func (r *Room) Prepare(checkAdjacentRooms bool) {
 					r.Gold = spawnInfo.Gold
 				}
 
+				if spawnInfo.LootTable != `` && lootTaken {
+					drop := loot.Roll(spawnInfo.LootTable, spawnInfo.Level)
+					r.Items = append(r.Items, drop.Items...) // just append to avoid a mutex double lock
+					r.Gold += drop.Gold
+
+					spawnInfo.LootItemIds = []int{}
+					for _, item := range drop.Items {
+						spawnInfo.LootItemIds = append(spawnInfo.LootItemIds, item.ItemId)
+					}
+					spawnInfo.LootGold = drop.Gold
+				}
+
 				spawnInfo.DespawnedRound = roundNow
 
 				r.SpawnInfo[idx] = spawnInfo
@@ -478,6 +517,13 @@ func (r *Room) Prepare(checkAdjacentRooms bool) {
 
 				container := r.Containers[containerName]
 
+				// Don't pile loot up in a container nobody has looted
+				if spawnInfo.LootTable != `` && len(container.Items) == 0 && container.Gold == 0 {
+					drop := loot.Roll(spawnInfo.LootTable, spawnInfo.Level)
+					container.Items = append(container.Items, drop.Items...)
+					container.Gold += drop.Gold
+				}
+
 				if _, alreadyExists := container.FindItem(fmt.Sprintf(`!%d`, spawnInfo.ItemId)); !alreadyExists {
 					if item := items.New(spawnInfo.ItemId); item.ItemId != 0 {
 						container.AddItem(item)
@@ -1797,6 +1843,12 @@ func (r *Room) Validate() error {
 		}
 	}
 
+	if r.Vehicle != nil {
+		if err := r.Vehicle.Validate(); err != nil {
+			return err
+		}
+	}
+
 	// Validate the biome.
 	if r.Biome != `` {
 		if _, found := GetBiome(r.Biome); !found {
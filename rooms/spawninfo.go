@@ -5,6 +5,7 @@ type SpawnInfo struct {
 	InstanceId   int      `yaml:"-"`                         // Mob instance Id that was spawned (tracks whether exists currently)
 	Container    string   `yaml:"container,omitempty"`       // If set, any item or gold spawned will go into the container.
 	ItemId       int      `yaml:"itemid,omitempty"`          // Item template Id to spawn on the floor
+	LootTable    string   `yaml:"loottable,omitempty"`       // Loot table to roll on the floor or in the container. Only rolled again once the last roll has been taken
 	Gold         int      `yaml:"gold,omitempty"`            // How much gold to spawn on the floor
 	Message      string   `yaml:"message,omitempty"`         // (optional) message to display to the room when this creature spawns, instead of a default
 	Name         string   `yaml:"name,omitempty"`            // (optional) if set, will override the mob's name
@@ -14,8 +15,12 @@ type SpawnInfo struct {
 	ScriptTag    string   `yaml:"scripttag,omitempty"`       // (optional) if set, will override the mob's script tag
 	QuestFlags   []string `yaml:"questflags,omitempty,flow"` // (optional) list of quest flags to set on the mob
 	BuffIds      []int    `yaml:"buffids,omitempty,flow"`    // (optional) list of buffs the mob always has active
-	Level        int      `yaml:"level,omitempty"`           // (optional) force this mob to a specific level
+	Level        int      `yaml:"level,omitempty"`           // (optional) force this mob to a specific level, or the level a loot table is rolled at
 	LevelMod     int      `yaml:"levelmod,omitempty"`        // (optional) modify this mobs level by this amount
+	MoonPhase    string   `yaml:"moonphase,omitempty"`       // (optional) only spawns during this moon phase, such as "noctherion:full"
+	// loot table tracking, so a floor isn't rolled again until the last roll has been taken
+	LootItemIds []int `yaml:"lootitemids,omitempty,flow"` // Items from the last roll
+	LootGold    int   `yaml:"lootgold,omitempty"`         // Gold from the last roll
 	// spawn tracking and rate
 	DespawnedRound uint64 `-`                          // When this mob was last despawned (killed)
 	RespawnRate    string `yaml:respawnrate:omitempty` // How long until it respawns when not present?
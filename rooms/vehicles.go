@@ -0,0 +1,204 @@
+package rooms
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/volte6/gomud/configs"
+	"github.com/volte6/gomud/util"
+)
+
+const (
+	VehicleDisembarkExit = `disembark`
+)
+
+// A single leg of a vehicle's route
+type VehicleStop struct {
+	RoomId  int    `yaml:"roomid"`            // Where the vehicle is during this leg of the route
+	Dock    bool   `yaml:"dock,omitempty"`    // Can passengers board and disembark here?
+	Hours   int    `yaml:"hours,omitempty"`   // How many game hours this leg lasts. Defaults to 1
+	Message string `yaml:"message,omitempty"` // Shown to passengers when this leg begins
+}
+
+// Turns a room into a vehicle (such as a boat) that travels along a route.
+// Where the vehicle is depends only on the game time, so it never needs to be saved.
+type VehicleInfo struct {
+	Name           string        `yaml:"name"`                     // Name of the exit passengers use to board, such as "ferry"
+	PassingMessage string        `yaml:"passingmessage,omitempty"` // Heard by anyone near the route while the vehicle is underway
+	Route          []VehicleStop `yaml:"route"`
+}
+
+type vehicleIndexEntry struct {
+	Vehicle VehicleInfo
+	StopIdx int // Which stop the vehicle was at when last checked. -1 if unknown
+}
+
+func (v *VehicleInfo) Validate() error {
+
+	if v.Name == `` {
+		return fmt.Errorf(`vehicle has no name`)
+	}
+
+	if len(v.Route) == 0 {
+		return fmt.Errorf(`vehicle %s has no route`, v.Name)
+	}
+
+	for i := range v.Route {
+		if v.Route[i].Hours < 1 {
+			v.Route[i].Hours = 1 // default
+		}
+	}
+
+	return nil
+}
+
+// Returns which stop of the route the vehicle is at for a given round,
+// and how many rounds until it moves on.
+func (v *VehicleInfo) GetStop(roundNumber uint64) (stopIdx int, roundsLeft int) {
+
+	roundsPerDay := int(configs.GetConfig().RoundsPerDay)
+
+	legRounds := make([]int, len(v.Route))
+	totalRounds := 0
+	for i, stop := range v.Route {
+		legRounds[i] = stop.Hours * roundsPerDay / 24
+		if legRounds[i] < 1 {
+			legRounds[i] = 1
+		}
+		totalRounds += legRounds[i]
+	}
+
+	pos := int(roundNumber % uint64(totalRounds))
+	for i, rounds := range legRounds {
+		if pos < rounds {
+			return i, rounds - pos
+		}
+		pos -= rounds
+	}
+
+	return 0, legRounds[0]
+}
+
+func (r *Room) IsVehicle() bool {
+	return r.Vehicle != nil
+}
+
+// Checks every vehicle and moves it along its route if it is time.
+// Docking adds temporary exits between the vehicle and the dock, which are removed on departure.
+func MoveVehicles() {
+
+	roundNow := util.GetRoundCount()
+
+	for roomId, entry := range roomManager.vehicles {
+
+		stopIdx, roundsLeft := entry.Vehicle.GetStop(roundNow)
+
+		if stopIdx == entry.StopIdx {
+			// Docks can be unloaded from memory while the vehicle waits, so make sure the way aboard is still there
+			if stop := entry.Vehicle.Route[stopIdx]; stop.Dock && IsRoomLoaded(stop.RoomId) {
+				dockVehicle(roomId, entry.Vehicle, stop, roundsLeft, false)
+			}
+			continue
+		}
+
+		if entry.StopIdx >= 0 {
+			if lastStop := entry.Vehicle.Route[entry.StopIdx]; lastStop.Dock {
+				undockVehicle(roomId, entry.Vehicle, lastStop)
+			}
+		}
+
+		stop := entry.Vehicle.Route[stopIdx]
+
+		// Only announce changes once the vehicle has been watched for a full leg
+		announce := entry.StopIdx >= 0
+
+		if stop.Dock {
+			dockVehicle(roomId, entry.Vehicle, stop, roundsLeft, announce)
+		} else if announce {
+			underwayVehicle(roomId, entry.Vehicle, stop)
+		}
+
+		entry.StopIdx = stopIdx
+		roomManager.vehicles[roomId] = entry
+	}
+
+}
+
+func dockVehicle(vehicleRoomId int, v VehicleInfo, stop VehicleStop, roundsLeft int, announce bool) {
+
+	vehicleRoom := LoadRoom(vehicleRoomId)
+	dockRoom := LoadRoom(stop.RoomId)
+	if vehicleRoom == nil || dockRoom == nil {
+		return
+	}
+
+	// Give it an extra round so that the vehicle removes the exits itself when it departs
+	expires := time.Now().Add(time.Duration(roundsLeft+1) * time.Duration(configs.GetConfig().RoundSeconds) * time.Second)
+
+	dockRoom.AddTemporaryExit(v.Name, TemporaryRoomExit{
+		RoomId:  vehicleRoomId,
+		Title:   v.Name,
+		Expires: expires,
+	})
+
+	vehicleRoom.AddTemporaryExit(VehicleDisembarkExit, TemporaryRoomExit{
+		RoomId:  stop.RoomId,
+		Title:   VehicleDisembarkExit,
+		Expires: expires,
+	})
+
+	if !announce {
+		return
+	}
+
+	if stop.Message != `` {
+		vehicleRoom.SendText(stop.Message)
+	}
+	vehicleRoom.SendText(fmt.Sprintf(`The %s has arrived at <ansi fg="yellow-bold">%s</ansi>. Take the <ansi fg="exit">%s</ansi> exit to go ashore.`, v.Name, dockRoom.Title, VehicleDisembarkExit))
+
+	dockRoom.SendText(fmt.Sprintf(`The %s pulls up alongside and is ready to take on passengers. Take the <ansi fg="exit">%s</ansi> exit to board.`, v.Name, v.Name))
+}
+
+func undockVehicle(vehicleRoomId int, v VehicleInfo, stop VehicleStop) {
+
+	if vehicleRoom := LoadRoom(vehicleRoomId); vehicleRoom != nil {
+		delete(vehicleRoom.ExitsTemp, VehicleDisembarkExit)
+		vehicleRoom.SendText(fmt.Sprintf(`The %s casts off and gets underway.`, v.Name))
+	}
+
+	if dockRoom := LoadRoom(stop.RoomId); dockRoom != nil {
+		delete(dockRoom.ExitsTemp, v.Name)
+		dockRoom.SendText(fmt.Sprintf(`The %s casts off and gets underway.`, v.Name))
+	}
+
+}
+
+func underwayVehicle(vehicleRoomId int, v VehicleInfo, stop VehicleStop) {
+
+	if stop.Message != `` {
+		if vehicleRoom := LoadRoom(vehicleRoomId); vehicleRoom != nil {
+			vehicleRoom.SendText(stop.Message)
+		}
+	}
+
+	if v.PassingMessage == `` {
+		return
+	}
+
+	if routeRoom := LoadRoom(stop.RoomId); routeRoom != nil {
+		routeRoom.SendText(v.PassingMessage)
+		routeRoom.SendTextToExits(v.PassingMessage, true)
+	}
+
+}
+
+func updateVehicleIndex(r *Room) {
+	if r.Vehicle == nil {
+		delete(roomManager.vehicles, r.RoomId)
+		return
+	}
+	roomManager.vehicles[r.RoomId] = vehicleIndexEntry{
+		Vehicle: *r.Vehicle,
+		StopIdx: -1,
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/volte6/gomud/mobs"
 	"github.com/volte6/gomud/parties"
 	"github.com/volte6/gomud/pets"
+	"github.com/volte6/gomud/quests"
 	"github.com/volte6/gomud/races"
 	"github.com/volte6/gomud/rooms"
 	"github.com/volte6/gomud/skills"
@@ -225,6 +226,18 @@ func (a ScriptActor) GiveQuest(questId string) {
 
 }
 
+func (a ScriptActor) IsQuestLocked(questId string) bool {
+	return a.characterRecord.IsQuestLocked(questId)
+}
+
+func (a ScriptActor) ChooseQuestBranch(questId string, branchId string) bool {
+	if a.userRecord == nil {
+		return false
+	}
+	qId, _ := quests.TokenToParts(questId)
+	return a.userRecord.ChooseQuestBranch(qId, branchId)
+}
+
 func (a ScriptActor) GetPartyMembers() []ScriptActor {
 
 	partyMembers := []ScriptActor{}
@@ -269,7 +282,11 @@ func (a ScriptActor) GetPartyMembers() []ScriptActor {
 }
 
 func (a ScriptActor) AddGold(amt int, bankAmt ...int) {
-	a.characterRecord.Gold += amt
+	if a.userRecord != nil {
+		a.userRecord.EarnGold(amt)
+	} else {
+		a.characterRecord.Gold += amt
+	}
 	if a.characterRecord.Gold < 0 {
 		a.characterRecord.Gold = 0
 	}
@@ -479,6 +496,18 @@ func (a ScriptActor) ChangeAlignment(alignmentChange int) {
 	a.characterRecord.UpdateAlignment(alignmentChange)
 }
 
+func (a ScriptActor) GetReputation(factionId string) int {
+	return a.characterRecord.GetReputation(factionId)
+}
+
+func (a ScriptActor) ChangeReputation(factionId string, reputationChange int) {
+	if a.userRecord != nil {
+		a.userRecord.AdjustReputation(factionId, reputationChange)
+		return
+	}
+	a.characterRecord.AdjustReputation(factionId, reputationChange)
+}
+
 func (a ScriptActor) HasSpell(spellId string) bool {
 	return a.characterRecord.HasSpell(spellId)
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/volte6/gomud/colorpatterns"
 	"github.com/volte6/gomud/configs"
 	"github.com/volte6/gomud/gametime"
+	"github.com/volte6/gomud/moons"
 	"github.com/volte6/gomud/users"
 	"github.com/volte6/gomud/util"
 )
@@ -26,6 +27,8 @@ func setUtilFunctions(vm *goja.Runtime) {
 	vm.Set(`UtilSetTimeDay`, UtilSetTimeDay)
 	vm.Set(`UtilSetTimeNight`, UtilSetTimeNight)
 	vm.Set(`UtilIsDay`, UtilIsDay)
+	vm.Set(`UtilGetMoonPhase`, UtilGetMoonPhase)
+	vm.Set(`UtilIsMoonPhase`, UtilIsMoonPhase)
 	vm.Set(`UtilLocateUser`, UtilLocateUser)
 	vm.Set(`UtilApplyColorPattern`, UtilApplyColorPattern)
 	vm.Set(`UtilGetConfig`, UtilGetConfig)
@@ -120,6 +123,14 @@ func UtilIsDay() bool {
 	return !gametime.IsNight()
 }
 
+func UtilGetMoonPhase(moonId string) string {
+	return moons.GetPhaseName(moonId)
+}
+
+func UtilIsMoonPhase(condition string) bool {
+	return moons.IsPhase(condition)
+}
+
 func UtilLocateUser(idOrName any) int {
 
 	// check if is string
@@ -34,6 +34,7 @@ const (
 	Protection  SkillTag = `protection`  // TODO
 	Tame        SkillTag = `tame`        // [LVL 1-4] Give mushroom to fairie in ROOM 558, train in ROOM 830
 	Trading     SkillTag = `trading`     // TODO
+	Repair      SkillTag = `repair`      // [LVL 1-4] Steelwhisper Armory - ROOM 63
 )
 
 var (
@@ -65,6 +66,7 @@ var (
 		"warrior": {
 			Brawling,
 			DualWield,
+			Repair,
 		},
 		"paladin": {
 			Protection,
@@ -86,6 +88,7 @@ var (
 		"merchant": {
 			Peep,
 			Trading,
+			Repair,
 		},
 	}
 )
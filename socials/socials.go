@@ -0,0 +1,104 @@
+package socials
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/volte6/gomud/configs"
+	"github.com/volte6/gomud/fileloader"
+	"github.com/volte6/gomud/items"
+)
+
+var (
+	loadedSocials Socials
+)
+
+// What each party sees when a social is done
+type Messages struct {
+	Source string `yaml:"source"`           // What the one doing it sees
+	Target string `yaml:"target,omitempty"` // What the one it's done to sees
+	Room   string `yaml:"room"`             // What everyone else sees
+}
+
+// A prewritten action such as smile or bow
+type Social struct {
+	NoTarget Messages  `yaml:"notarget"`         // Done to nobody in particular
+	Target   *Messages `yaml:"target,omitempty"` // Done to someone else. If missing, it can't be done to anyone
+	Self     *Messages `yaml:"self,omitempty"`   // Done to yourself. If missing, the untargeted version is used
+}
+
+type Socials map[string]*Social
+
+func (s *Socials) Filename() string {
+	return string(configs.GetConfig().FileSocials)
+}
+
+func (s *Socials) Filepath() string {
+	return s.Filename()
+}
+
+func (s *Socials) Validate() error {
+
+	for name, social := range *s {
+
+		if social == nil || social.NoTarget.Source == `` || social.NoTarget.Room == `` {
+			return fmt.Errorf(`social %s: notarget needs a source and room message`, name)
+		}
+
+		if social.Target != nil && (social.Target.Source == `` || social.Target.Target == `` || social.Target.Room == ``) {
+			return fmt.Errorf(`social %s: target needs a source, target and room message`, name)
+		}
+
+		if social.Self == nil {
+			social.Self = &social.NoTarget
+		}
+
+		if lowerName := strings.ToLower(name); lowerName != name {
+			delete(*s, name)
+			(*s)[lowerName] = social
+		}
+	}
+
+	return nil
+}
+
+// Returns nil if there's no social by that name
+func Get(name string) *Social {
+	return loadedSocials[strings.ToLower(name)]
+}
+
+// Returns the names of all socials, sorted
+func Names() []string {
+
+	names := make([]string, 0, len(loadedSocials))
+	for name := range loadedSocials {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Fills in who is doing it, and who it's being done to
+func Format(msg string, sourceName string, targetName string) string {
+	msg = strings.ReplaceAll(msg, string(items.TokenSource), sourceName)
+	msg = strings.ReplaceAll(msg, string(items.TokenTarget), targetName)
+	return msg
+}
+
+func LoadDataFile() {
+
+	start := time.Now()
+
+	loaded, err := fileloader.LoadFlatFile[*Socials](string(configs.GetConfig().FileSocials))
+	if err != nil {
+		panic(err)
+	}
+
+	loadedSocials = *loaded
+
+	slog.Info("socials.LoadDataFile()", "loadedCount", len(loadedSocials), "Time Taken", time.Since(start))
+}
@@ -0,0 +1,25 @@
+package socials
+
+import (
+	"testing"
+
+	"github.com/volte6/gomud/fileloader"
+)
+
+func TestDataFile(t *testing.T) {
+
+	loaded, err := fileloader.LoadFlatFile[*Socials](`../_datafiles/socials.yaml`)
+	if err != nil {
+		t.Fatalf("LoadFlatFile() error = %v", err)
+	}
+
+	smile, ok := (*loaded)[`smile`]
+	if !ok || smile.Target == nil || smile.Self == nil {
+		t.Fatalf("smile = %+v, want a social with target and self messages", smile)
+	}
+
+	got := Format(smile.Target.Room, `Bobbin`, `Carlotta`)
+	if want := `Bobbin smiles warmly at Carlotta.`; got != want {
+		t.Errorf("Format() = %q, want %q", got, want)
+	}
+}
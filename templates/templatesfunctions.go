@@ -122,6 +122,7 @@ var (
 			return str
 		},
 		"splitstring": SplitStringNL,
+		"splitlines":  util.SplitLinesNL,
 		"ansiparse":   TplAnsiParse,
 		"buffname": func(buffId int) string {
 			buffSpec := buffs.GetBuffSpec(buffId)
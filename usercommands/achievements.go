@@ -0,0 +1,122 @@
+package usercommands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/volte6/gomud/achievements"
+	"github.com/volte6/gomud/rooms"
+	"github.com/volte6/gomud/templates"
+	"github.com/volte6/gomud/users"
+	"github.com/volte6/gomud/util"
+)
+
+func Achievements(rest string, user *users.UserRecord, room *rooms.Room) (bool, error) {
+
+	args := util.SplitButRespectQuotes(rest)
+
+	if len(args) > 0 && strings.ToLower(args[0]) == `title` {
+		titleRest, _ := strings.CutPrefix(strings.TrimSpace(rest), args[0])
+		return achievementTitle(strings.TrimSpace(titleRest), user)
+	}
+
+	headers := []string{`Achievement`, `Description`, `Progress`, `Title`}
+	rows := [][]string{}
+	formatting := [][]string{}
+
+	unlockedCt := 0
+	for _, achievementId := range achievements.GetAchievementIds() {
+
+		a := achievements.GetAchievement(achievementId)
+
+		progressStr := ``
+		progressColor := `yellow`
+
+		if user.Character.Achievements.Has(achievementId) {
+			unlockedCt++
+			progressStr = `unlocked`
+			progressColor = `green`
+		} else if a.Secret {
+			continue
+		} else {
+			progressStr = fmt.Sprintf(`%d/%d`, a.Criteria.Progress(user), a.Criteria.Quantity)
+		}
+
+		rows = append(rows, []string{
+			a.Name,
+			a.Description,
+			progressStr,
+			a.Title,
+		})
+
+		formatting = append(formatting, []string{
+			`<ansi fg="white-bold">%s</ansi>`,
+			`<ansi fg="7">%s</ansi>`,
+			`<ansi fg="` + progressColor + `">%s</ansi>`,
+			`<ansi fg="title">%s</ansi>`,
+		})
+	}
+
+	if len(rows) == 0 {
+		user.SendText(`There are no achievements to earn.`)
+		return true, nil
+	}
+
+	achievementTableData := templates.GetTable(fmt.Sprintf(`Achievements (%d unlocked, %d points)`, unlockedCt, achievements.GetPoints(user.Character.Achievements)), headers, rows, formatting...)
+	achievementTxt, _ := templates.Process("tables/generic", achievementTableData)
+	user.SendText(achievementTxt)
+
+	if user.Character.Title != `` {
+		user.SendText(fmt.Sprintf(`You are known as <ansi fg="username">%s</ansi> <ansi fg="title">%s</ansi>.`, user.Character.Name, user.Character.Title))
+	}
+	user.SendText(`Type <ansi fg="command">achievements title</ansi> to choose which title you wear.`)
+
+	return true, nil
+}
+
+// Lists or changes the title a user wears after their name
+func achievementTitle(rest string, user *users.UserRecord) (bool, error) {
+
+	titles := []string{}
+	for _, achievementId := range achievements.GetAchievementIds() {
+		if a := achievements.GetAchievement(achievementId); a.Title != `` && user.Character.Achievements.Has(achievementId) {
+			titles = append(titles, a.Title)
+		}
+	}
+
+	if rest == `` {
+
+		if len(titles) == 0 {
+			user.SendText(`You haven't earned any titles yet. Unlock <ansi fg="command">achievements</ansi> to earn them.`)
+			return true, nil
+		}
+
+		user.SendText(`You have earned these titles:`)
+		for _, title := range titles {
+			user.SendText(fmt.Sprintf(`  <ansi fg="title">%s</ansi>`, title))
+		}
+		user.SendText(`Type <ansi fg="command">achievements title [title]</ansi> to wear one, or <ansi fg="command">achievements title none</ansi> to go without.`)
+		return true, nil
+	}
+
+	if strings.ToLower(rest) == `none` {
+		user.Character.Title = ``
+		user.SendText(`You no longer wear a title.`)
+		return true, nil
+	}
+
+	match, closeMatch := util.FindMatchIn(rest, titles...)
+	if match == `` {
+		match = closeMatch
+	}
+
+	if match == `` {
+		user.SendText(fmt.Sprintf(`You haven't earned the title "%s".`, rest))
+		return true, nil
+	}
+
+	user.Character.Title = match
+	user.SendText(fmt.Sprintf(`You will now be known as <ansi fg="username">%s</ansi> <ansi fg="title">%s</ansi>.`, user.Character.Name, match))
+
+	return true, nil
+}
@@ -0,0 +1,195 @@
+package usercommands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/volte6/gomud/chatlog"
+	"github.com/volte6/gomud/configs"
+	"github.com/volte6/gomud/rooms"
+	"github.com/volte6/gomud/templates"
+	"github.com/volte6/gomud/users"
+)
+
+const (
+	chatLogSearchLimit = 50
+	chatLogReportLimit = 20
+)
+
+func ChatLog(rest string, user *users.UserRecord, room *rooms.Room) (bool, error) {
+
+	args := strings.Fields(rest)
+
+	if len(args) == 0 {
+		infoOutput, _ := templates.Process("admincommands/help/command.chatlog", nil)
+		user.SendText(infoOutput)
+		return true, nil
+	}
+
+	if strings.EqualFold(args[0], `reports`) {
+		chatLogReports(user)
+		return true, nil
+	}
+
+	if strings.EqualFold(args[0], `report`) {
+		if len(args) < 2 {
+			user.SendText(`Type <ansi fg="command">chatlog report [report id]</ansi>. See <ansi fg="command">chatlog reports</ansi> for a list.`)
+			return true, nil
+		}
+		chatLogReport(args[1], user)
+		return true, nil
+	}
+
+	since := 24 * time.Hour
+	if len(args) > 1 {
+		d, err := parseSince(args[len(args)-1])
+		if err != nil {
+			user.SendText(fmt.Sprintf(`"%s" isn't a time span. Try something like <ansi fg="command">30m</ansi>, <ansi fg="command">2h</ansi> or <ansi fg="command">3d</ansi>.`, args[len(args)-1]))
+			return true, nil
+		}
+		since = d
+		args = args[:len(args)-1]
+	}
+
+	target := strings.Join(args, ` `)
+
+	var match func(chatlog.Entry) bool
+	var title string
+
+	if roomId, err := strconv.Atoi(target); err == nil {
+
+		match = func(e chatlog.Entry) bool {
+			return e.RoomId == roomId
+		}
+		title = fmt.Sprintf(`Room #%d`, roomId)
+
+	} else {
+
+		u := findPlayer(target)
+		if u == nil {
+			user.SendText(fmt.Sprintf(`There's nobody named %s.`, target))
+			return true, nil
+		}
+
+		match = func(e chatlog.Entry) bool {
+			return e.Involves(u.UserId)
+		}
+		title = u.Character.Name
+	}
+
+	entries, err := chatlog.Search(time.Now().Add(-since), match, chatLogSearchLimit)
+	if err != nil {
+		user.SendText(fmt.Sprintf(`Could not search the chat logs: %s`, err))
+		return true, nil
+	}
+
+	if len(entries) == 0 {
+		user.SendText(fmt.Sprintf(`Nothing was logged for <ansi fg="username">%s</ansi> in the last %s.`, title, sinceText(since)))
+		return true, nil
+	}
+
+	user.SendText(fmt.Sprintf(`Chat logged for <ansi fg="username">%s</ansi> in the last %s:`, title, sinceText(since)))
+	sendChatLogEntries(user, entries)
+
+	return true, nil
+}
+
+func chatLogReports(user *users.UserRecord) {
+
+	reports, err := chatlog.GetReports(chatLogReportLimit)
+	if err != nil || len(reports) == 0 {
+		user.SendText(`There are no player reports.`)
+		return
+	}
+
+	tFormat := string(configs.GetConfig().TimeFormat)
+
+	headers := []string{`Id`, `When`, `Reporter`, `Reported`, `Reason`}
+	rows := [][]string{}
+	formatting := [][]string{}
+
+	for _, r := range reports {
+		reason := r.Reason
+		if len(reason) > 30 {
+			reason = reason[:27] + `...`
+		}
+		rows = append(rows, []string{r.ReportId, r.When.Format(tFormat), r.ReporterName, r.ReportedName, reason})
+		formatting = append(formatting, []string{`<ansi fg="yellow-bold">%s</ansi>`, `%s`, `<ansi fg="username">%s</ansi>`, `<ansi fg="username">%s</ansi>`, `%s`})
+	}
+
+	reportTableData := templates.GetTable(`Player Reports`, headers, rows, formatting...)
+	reportTxt, _ := templates.Process("tables/generic", reportTableData)
+	user.SendText(reportTxt)
+	user.SendText(`Type <ansi fg="command">chatlog report [report id]</ansi> to read one.`)
+}
+
+func chatLogReport(reportId string, user *users.UserRecord) {
+
+	r, err := chatlog.GetReport(reportId)
+	if err != nil {
+		user.SendText(fmt.Sprintf(`Report %s could not be found.`, reportId))
+		return
+	}
+
+	tFormat := string(configs.GetConfig().TimeFormat)
+
+	user.SendText(fmt.Sprintf(`<ansi fg="yellow-bold">Report %s</ansi> - %s`, r.ReportId, r.When.Format(tFormat)))
+	user.SendText(fmt.Sprintf(`  <ansi fg="username">%s</ansi> reported <ansi fg="username">%s</ansi> in room #%d`, r.ReporterName, r.ReportedName, r.RoomId))
+	user.SendText(fmt.Sprintf(`  Reason: %s`, r.Reason))
+
+	if len(r.Lines) == 0 {
+		user.SendText(`  No chat was captured with this report.`)
+		return
+	}
+
+	user.SendText(``)
+	sendChatLogEntries(user, r.Lines)
+}
+
+func sendChatLogEntries(user *users.UserRecord, entries []chatlog.Entry) {
+
+	tFormat := string(configs.GetConfig().TimeFormat)
+
+	for _, e := range entries {
+		to := ``
+		if e.ToName != `` {
+			to = fmt.Sprintf(` -> <ansi fg="username">%s</ansi>`, e.ToName)
+		}
+		user.SendText(
+			fmt.Sprintf(`<ansi fg="black-bold">[%s]</ansi> <ansi fg="yellow">#%d</ansi> <ansi fg="magenta">%s</ansi> <ansi fg="username">%s</ansi>%s: %s`, e.When.Format(tFormat), e.RoomId, e.Kind, e.FromName, to, e.Text),
+		)
+	}
+}
+
+// Drops the zero minutes and seconds from a duration, so 24h0m0s reads 24h
+func sinceText(d time.Duration) string {
+	txt := d.String()
+	if strings.HasSuffix(txt, `m0s`) {
+		txt = strings.TrimSuffix(txt, `0s`)
+	}
+	if strings.HasSuffix(txt, `h0m`) {
+		txt = strings.TrimSuffix(txt, `0m`)
+	}
+	return txt
+}
+
+// Accepts the usual durations (30m, 2h) as well as days (3d)
+func parseSince(s string) (time.Duration, error) {
+
+	if days, ok := strings.CutSuffix(strings.ToLower(s), `d`); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 1 {
+			return 0, fmt.Errorf(`invalid number of days: %s`, s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf(`invalid duration: %s`, s)
+	}
+
+	return d, nil
+}
@@ -17,12 +17,20 @@ func Deafen(rest string, user *users.UserRecord, room *rooms.Room) (bool, error)
 		return true, nil
 	}
 
+	rest, ch := splitChannelArg(rest)
+
 	targetUserId, _ := room.FindByName(rest)
 
 	if targetUserId > 0 {
 
 		if u := users.GetByUserId(targetUserId); u != nil {
 
+			if ch != nil {
+				u.SetDeafenedOn(ch.ChannelId, true)
+				user.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> (<ansi fg="username">%s</ansi>) has been <ansi fg="alert-5">DEAFENED</ansi> on <ansi fg="%s">%s</ansi>`, u.Username, u.Character.Name, ch.Color, ch.Name))
+				return true, nil
+			}
+
 			u.Deafened = true
 
 			user.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> (<ansi fg="username">%s</ansi>) has been <ansi fg="alert-5">DEAFENED</ansi>`, u.Username, u.Character.Name))
@@ -44,12 +52,20 @@ func UnDeafen(rest string, user *users.UserRecord, room *rooms.Room) (bool, erro
 		return true, nil
 	}
 
+	rest, ch := splitChannelArg(rest)
+
 	targetUserId, _ := room.FindByName(rest)
 
 	if targetUserId > 0 {
 
 		if u := users.GetByUserId(targetUserId); u != nil {
 
+			if ch != nil {
+				u.SetDeafenedOn(ch.ChannelId, false)
+				user.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> (<ansi fg="username">%s</ansi>) has been <ansi fg="alert-1">UNDEAFENED</ansi> on <ansi fg="%s">%s</ansi>`, u.Username, u.Character.Name, ch.Color, ch.Name))
+				return true, nil
+			}
+
 			u.Deafened = false
 
 			user.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> (<ansi fg="username">%s</ansi>) has been <ansi fg="alert-1">UNDEAFENED</ansi>`, u.Username, u.Character.Name))
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/volte6/gomud/channels"
 	"github.com/volte6/gomud/rooms"
 	"github.com/volte6/gomud/templates"
 	"github.com/volte6/gomud/util"
@@ -100,5 +101,65 @@ func Modify(rest string, user *users.UserRecord, room *rooms.Room) (bool, error)
 
 	}
 
+	// modify clan <username> <clantag/none>
+	if args[0] == `clan` {
+
+		searchUser := args[1]
+		newClanTag := args[2]
+
+		if strings.EqualFold(newClanTag, `none`) {
+			newClanTag = ``
+		}
+
+		foundUsername := ``
+		foundCharacterName := ``
+
+		for _, u := range users.GetAllActiveUsers() {
+			if strings.EqualFold(searchUser, u.Username) {
+
+				foundCharacterName = u.Character.Name
+				foundUsername = u.Username
+
+				u.Character.ClanTag = newClanTag
+
+				users.SaveUser(*u)
+
+				if newClanTag == `` {
+					u.SendText(`<ansi fg="alert-3">You are no longer in a clan.</ansi>`)
+				} else {
+					u.SendText(`<ansi fg="alert-3">You are now in the clan: ` + newClanTag + `</ansi>`)
+				}
+
+				channels.SendGMCPList(u)
+				break
+			}
+		}
+
+		if len(foundUsername) == 0 {
+			users.SearchOfflineUsers(func(u *users.UserRecord) bool {
+
+				if strings.EqualFold(searchUser, u.Username) {
+
+					foundCharacterName = u.Character.Name
+					foundUsername = u.Username
+
+					u.Character.ClanTag = newClanTag
+
+					users.SaveUser(*u)
+
+					return false
+				}
+
+				return true
+			})
+		}
+
+		if len(foundUsername) > 0 {
+			user.SendText(fmt.Sprintf(`Clan changed for user <ansi fg="username">%s</ansi> (Character name: <ansi fg="username">%s</ansi>).`, foundUsername, foundCharacterName))
+			return true, nil
+		}
+
+	}
+
 	return true, nil
 }
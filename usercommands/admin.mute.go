@@ -2,7 +2,9 @@ package usercommands
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/volte6/gomud/channels"
 	"github.com/volte6/gomud/rooms"
 	"github.com/volte6/gomud/templates"
 
@@ -17,13 +19,21 @@ func Mute(rest string, user *users.UserRecord, room *rooms.Room) (bool, error) {
 		return true, nil
 	}
 
+	rest, ch := splitChannelArg(rest)
+
 	targetUserId, _ := room.FindByName(rest)
 
 	if targetUserId > 0 {
 
 		if u := users.GetByUserId(targetUserId); u != nil {
 
-			u.Muted = true
+			if ch != nil {
+				u.SetMutedOn(ch.ChannelId, true)
+				user.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> (<ansi fg="username">%s</ansi>) has been <ansi fg="alert-5">MUTED</ansi> on <ansi fg="%s">%s</ansi>`, u.Username, u.Character.Name, ch.Color, ch.Name))
+				return true, nil
+			}
+
+			u.SetMuted(true)
 
 			user.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> (<ansi fg="username">%s</ansi>) has been <ansi fg="alert-5">MUTED</ansi>`, u.Username, u.Character.Name))
 
@@ -44,13 +54,21 @@ func UnMute(rest string, user *users.UserRecord, room *rooms.Room) (bool, error)
 		return true, nil
 	}
 
+	rest, ch := splitChannelArg(rest)
+
 	targetUserId, _ := room.FindByName(rest)
 
 	if targetUserId > 0 {
 
 		if u := users.GetByUserId(targetUserId); u != nil {
 
-			u.Muted = false
+			if ch != nil {
+				u.SetMutedOn(ch.ChannelId, false)
+				user.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> (<ansi fg="username">%s</ansi>) has been <ansi fg="alert-1">UNMUTED</ansi> on <ansi fg="%s">%s</ansi>`, u.Username, u.Character.Name, ch.Color, ch.Name))
+				return true, nil
+			}
+
+			u.SetMuted(false)
 
 			user.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> (<ansi fg="username">%s</ansi>) has been <ansi fg="alert-1">UNMUTED</ansi>`, u.Username, u.Character.Name))
 
@@ -62,3 +80,18 @@ func UnMute(rest string, user *users.UserRecord, room *rooms.Room) (bool, error)
 	user.SendText("Could not find user.")
 	return true, nil
 }
+
+// If the last word is the id of a chat channel, splits it off the rest
+func splitChannelArg(rest string) (string, *channels.Channel) {
+
+	idx := strings.LastIndex(rest, ` `)
+	if idx < 0 {
+		return rest, nil
+	}
+
+	if ch := channels.Get(rest[idx+1:]); ch != nil {
+		return strings.TrimSpace(rest[:idx]), ch
+	}
+
+	return rest, nil
+}
@@ -14,39 +14,58 @@ func Redescribe(rest string, user *users.UserRecord, room *rooms.Room) (bool, er
 
 	args := util.SplitButRespectQuotes(rest)
 
-	if len(args) < 2 {
+	if len(args) < 1 {
 		// send some sort of help info?
-		infoOutput, _ := templates.Process("admincommands/help/command.rename", nil)
+		infoOutput, _ := templates.Process("admincommands/help/command.redescribe", nil)
 		user.SendText(infoOutput)
 		return true, nil
 	}
 
 	// Check whether the user has an item in their inventory that matches
 	matchItem, found := user.Character.FindInBackpack(args[0])
-	rest = strings.Join(args[1:], " ")
 
 	if !found {
-		user.SendText(fmt.Sprintf("You don't have a %s to rename.", rest))
-	} else {
-		// Swap the item location
-		user.Character.RemoveItem(matchItem)
-		oldNameSimple := matchItem.DisplayName()
-		oldName := matchItem.DisplayName()
-
-		matchItem.Redescribe(rest)
-
-		matchItem.Validate()
-
-		user.Character.StoreItem(matchItem)
-
-		user.SendText(
-			fmt.Sprintf(`You chant softly and wave your hand over the <ansi fg="item">%s</ansi>. Success! It now has a new description!`, oldNameSimple),
-		)
-		room.SendText(
-			fmt.Sprintf(`<ansi fg="username">%s</ansi> chants softly and waves their hand over <ansi fg="item">%s</ansi>, causing it to glow briefly.`, user.Character.Name, oldName),
-			user.UserId,
-		)
+		user.ClearPrompt()
+		user.SendText(fmt.Sprintf("You don't have a %s to redescribe.", args[0]))
+		return true, nil
+	}
+
+	// Without a description, write one in the line editor
+	if len(args) < 2 {
+		cmdPrompt, _ := user.StartPrompt(`redescribe`, rest)
+		question := cmdPrompt.AskParagraph(fmt.Sprintf(`Describe the %s`, matchItem.DisplayName()), matchItem.GetSpec().Description)
+		if !question.Done {
+			return true, nil
+		}
+		user.ClearPrompt()
+
+		if question.Response == `` {
+			user.SendText(`The description was not changed.`)
+			return true, nil
+		}
+		args = append(args, question.Response)
 	}
 
+	rest = strings.Join(args[1:], " ")
+
+	// Swap the item location
+	user.Character.RemoveItem(matchItem)
+	oldNameSimple := matchItem.DisplayName()
+	oldName := matchItem.DisplayName()
+
+	matchItem.Redescribe(rest)
+
+	matchItem.Validate()
+
+	user.Character.StoreItem(matchItem)
+
+	user.SendText(
+		fmt.Sprintf(`You chant softly and wave your hand over the <ansi fg="item">%s</ansi>. Success! It now has a new description!`, oldNameSimple),
+	)
+	room.SendText(
+		fmt.Sprintf(`<ansi fg="username">%s</ansi> chants softly and waves their hand over <ansi fg="item">%s</ansi>, causing it to glow briefly.`, user.Character.Name, oldName),
+		user.UserId,
+	)
+
 	return true, nil
 }
@@ -3,8 +3,10 @@ package usercommands
 import (
 	"strings"
 
+	"github.com/volte6/gomud/chatfilter"
 	"github.com/volte6/gomud/items"
 	"github.com/volte6/gomud/rooms"
+	"github.com/volte6/gomud/socials"
 	"github.com/volte6/gomud/templates"
 	"github.com/volte6/gomud/users"
 )
@@ -21,6 +23,12 @@ func Reload(rest string, user *users.UserRecord, room *rooms.Room) (bool, error)
 	case `items`:
 		items.LoadDataFiles()
 		user.SendText(`Items reloaded.`)
+	case `chatfilters`:
+		chatfilter.LoadDataFiles()
+		user.SendText(`Chat filters reloaded.`)
+	case `socials`:
+		socials.LoadDataFile()
+		user.SendText(`Socials reloaded.`)
 	default:
 		user.SendText(`Unknown reload command.`)
 	}
@@ -194,8 +194,20 @@ func Room(rest string, user *users.UserRecord, room *rooms.Room) (bool, error) {
 			room.Title = propertyValue
 			rooms.SaveRoom(*room)
 		} else if propertyName == "description" {
+			// Longer descriptions are easier to write in the line editor
 			if propertyValue == `` {
-				propertyValue = `[no description]`
+				cmdPrompt, _ := user.StartPrompt(`room`, rest)
+				question := cmdPrompt.AskParagraph(fmt.Sprintf(`Description of room #%d`, room.RoomId), room.GetDescription())
+				if !question.Done {
+					return handled, nil
+				}
+				user.ClearPrompt()
+
+				if question.Response == `` {
+					user.SendText(`The description was not changed.`)
+					return handled, nil
+				}
+				propertyValue = question.Response
 			}
 			room.Description = propertyValue
 			rooms.SaveRoom(*room)
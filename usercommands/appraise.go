@@ -38,15 +38,23 @@ func Appraise(rest string, user *users.UserRecord, room *rooms.Room) (bool, erro
 		}
 
 		type inspectDetails struct {
-			InspectLevel int
-			Item         *items.Item
-			ItemSpec     *items.ItemSpec
+			InspectLevel   int
+			Item           *items.Item
+			ItemSpec       *items.ItemSpec
+			ItemSet        *items.ItemSet
+			SetPiecesOwned int
+			SetPiecesWorn  int
 		}
 
 		details := inspectDetails{
 			InspectLevel: 2,
 			Item:         &item,
 			ItemSpec:     &itemSpec,
+			ItemSet:      items.GetItemSetFor(item.ItemId),
+		}
+
+		if details.ItemSet != nil {
+			details.SetPiecesOwned, details.SetPiecesWorn = user.Character.ItemSetPieces(details.ItemSet)
 		}
 
 		appraisePrice := 20
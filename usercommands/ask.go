@@ -5,8 +5,10 @@ import (
 	"strings"
 
 	"github.com/volte6/gomud/configs"
+	"github.com/volte6/gomud/events"
 	"github.com/volte6/gomud/keywords"
 	"github.com/volte6/gomud/mobs"
+	"github.com/volte6/gomud/quests"
 	"github.com/volte6/gomud/rooms"
 	"github.com/volte6/gomud/scripting"
 	"github.com/volte6/gomud/util"
@@ -125,6 +127,12 @@ func Ask(rest string, user *users.UserRecord, room *rooms.Room) (bool, error) {
 			}
 		}
 
+		events.AddToQueue(events.QuestObjective{
+			UserId:        user.UserId,
+			ObjectiveType: string(quests.ObjectiveTalk),
+			MobId:         int(mob.MobId),
+		})
+
 		rest = strings.Join(args, ` `)
 		if handled, err := scripting.TryMobScriptEvent(`onAsk`, mobId, user.UserId, `user`, map[string]any{"askText": rest}); err == nil {
 
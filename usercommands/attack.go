@@ -163,7 +163,8 @@ func Attack(rest string, user *users.UserRecord, room *rooms.Room) (bool, error)
 
 	} else if attackPlayerId > 0 {
 
-		if !configs.GetConfig().PVPEnabled {
+		// Arenas always allow PVP
+		if !bool(configs.GetConfig().PVPEnabled) && !room.IsArena {
 			user.SendText(`PVP is currently disabled.`)
 			return true, nil
 		}
@@ -0,0 +1,488 @@
+package usercommands
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/volte6/gomud/boards"
+	"github.com/volte6/gomud/chatlog"
+	"github.com/volte6/gomud/configs"
+	"github.com/volte6/gomud/prompt"
+	"github.com/volte6/gomud/rooms"
+	"github.com/volte6/gomud/templates"
+	"github.com/volte6/gomud/term"
+	"github.com/volte6/gomud/users"
+	"github.com/volte6/gomud/util"
+)
+
+const (
+	BoardReplyToKey = `board-replyto` // PostId of whatever a post being written is replying to
+	BoardSubjectKey = `board-subject` // Subject of the post being written, once it has passed the chat filter
+	BoardBodyKey    = `board-body`    // Body of the post being written, once it has passed the chat filter
+
+	boardSubjectMaxLength = 60
+)
+
+// Reads and writes posts on a bulletin board in the room.
+// Admins can also place new boards, or take them down.
+func Board(rest string, user *users.UserRecord, room *rooms.Room) (bool, error) {
+
+	args := util.SplitButRespectQuotes(rest)
+
+	cmd := ``
+	if len(args) > 0 {
+		cmd = strings.ToLower(args[0])
+		args = args[1:]
+	}
+
+	if cmd == `place` || cmd == `remove` {
+		if user.Permission != users.PermissionAdmin && !user.HasAdminCommand(`board`) {
+			user.SendText(`Try <ansi fg="command">help board</ansi> to see what you can do with a bulletin board.`)
+			return true, nil
+		}
+		if cmd == `place` {
+			return boardPlace(args, user, room)
+		}
+		return boardRemove(user, room)
+	}
+
+	if room.Board == `` {
+		user.ClearPrompt()
+		user.SendText(`There is no bulletin board here.`)
+		return true, nil
+	}
+
+	board, err := boards.Get(room.Board)
+	if err != nil {
+		user.ClearPrompt()
+		user.SendText(fmt.Sprintf(`The bulletin board here can't be read: %s`, err))
+		return true, nil
+	}
+
+	switch cmd {
+
+	case ``, `list`:
+
+		boardList(board, user)
+
+	case `read`:
+
+		var post boards.Post
+		var ok bool
+
+		if len(args) == 0 {
+			if post, ok = board.NextUnread(user.UserId); !ok {
+				user.SendText(`You've read everything on this board.`)
+				return true, nil
+			}
+		} else if post, ok = boardFindPost(board, args[0], user); !ok {
+			return true, nil
+		}
+
+		boardRead(board, post, user, room)
+
+	case `post`, `write`:
+
+		return boardCompose(rest, board, 0, user, room)
+
+	case `reply`:
+
+		if len(args) == 0 {
+			user.SendText(`Reply to which post? Type <ansi fg="command">board reply [#]</ansi>.`)
+			return true, nil
+		}
+
+		post, ok := boardFindPost(board, args[0], user)
+		if !ok {
+			user.ClearPrompt()
+			return true, nil
+		}
+
+		// Replies always go on the post that started the thread
+		if post.ReplyTo > 0 {
+			post.PostId = post.ReplyTo
+		}
+
+		return boardCompose(rest, board, post.PostId, user, room)
+
+	case `delete`:
+
+		if len(args) == 0 {
+			user.SendText(`Delete which post? Type <ansi fg="command">board delete [#]</ansi>.`)
+			return true, nil
+		}
+
+		post, ok := boardFindPost(board, args[0], user)
+		if !ok {
+			return true, nil
+		}
+
+		isMod := user.Permission == users.PermissionAdmin || user.Permission == users.PermissionMod
+		if post.AuthorUserId != user.UserId && !isMod {
+			user.SendText(`You can only take down your own posts.`)
+			return true, nil
+		}
+
+		removedCt, err := board.DeletePost(post.PostId)
+		if err != nil {
+			user.SendText(fmt.Sprintf(`Post #%d could not be taken down: %s`, post.PostId, err))
+			return true, nil
+		}
+
+		if removedCt > 1 {
+			user.SendText(fmt.Sprintf(`Post #%d and %d replies have been taken down.`, post.PostId, removedCt-1))
+		} else {
+			user.SendText(fmt.Sprintf(`Post #%d has been taken down.`, post.PostId))
+		}
+		room.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> takes something down from the bulletin board.`, user.Character.Name), user.UserId)
+
+	default:
+
+		user.SendText(`Try <ansi fg="command">help board</ansi> to see what you can do with a bulletin board.`)
+	}
+
+	return true, nil
+}
+
+func boardList(board *boards.Board, user *users.UserRecord) {
+
+	posts := board.GetPosts()
+
+	if len(posts) == 0 {
+		user.SendText(fmt.Sprintf(`Nothing has been posted on <ansi fg="yellow-bold">%s</ansi> yet. Type <ansi fg="command">board post</ansi> to write something.`, board.Name))
+		return
+	}
+
+	title := fmt.Sprintf(`%s (%d/%d)`, board.Name, len(posts), configs.GetConfig().BoardMaxPosts)
+
+	headers := []string{`#`, `Subject`, `Author`, `Posted`}
+	rows := [][]string{}
+	formatting := [][]string{}
+
+	addRow := func(p boards.Post, subject string) {
+
+		numberColor := `7`
+		if !p.IsReadBy(user.UserId) {
+			numberColor = `alert-5`
+		}
+
+		rows = append(rows, []string{
+			fmt.Sprintf(`%d.`, p.PostId),
+			subject,
+			p.AuthorName,
+			p.DateString(),
+		})
+
+		formatting = append(formatting, []string{
+			`<ansi fg="` + numberColor + `">%s</ansi>`,
+			`<ansi fg="mail-title">%s</ansi>`,
+			`<ansi fg="username">%s</ansi>`,
+			`<ansi fg="mail-date">%s</ansi>`,
+		})
+	}
+
+	// Each thread is listed with its replies under it
+	for _, p := range posts {
+		if p.ReplyTo > 0 {
+			continue
+		}
+		addRow(p, p.Subject)
+		for _, r := range posts {
+			if r.ReplyTo == p.PostId {
+				addRow(r, `  `+r.Subject)
+			}
+		}
+	}
+
+	boardTableData := templates.GetTable(title, headers, rows, formatting...)
+	boardTxt, _ := templates.Process("tables/generic", boardTableData)
+	user.SendText(boardTxt)
+
+	if unread := board.UnreadCount(user.UserId); unread > 0 {
+		user.SendText(fmt.Sprintf(`You have <ansi fg="alert-5">%d</ansi> unread. Type <ansi fg="command">board read</ansi> to read the next one, or <ansi fg="command">help board</ansi> for more.`, unread))
+		return
+	}
+
+	user.SendText(`Type <ansi fg="command">board read [#]</ansi> to read a post, or <ansi fg="command">help board</ansi> for more.`)
+}
+
+func boardRead(board *boards.Board, post boards.Post, user *users.UserRecord, room *rooms.Room) {
+
+	replies := board.Replies(post.PostId)
+
+	tplTxt, _ := templates.Process("boards/post", map[string]any{
+		`Post`:    post,
+		`Read`:    post.IsReadBy(user.UserId),
+		`Replies`: replies,
+		`UserId`:  user.UserId,
+	})
+	user.SendText(tplTxt)
+
+	board.MarkRead(post.PostId, user.UserId)
+
+	room.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> reads something on the bulletin board.`, user.Character.Name), user.UserId)
+}
+
+// Finds a post by its number, telling the user if it isn't there
+func boardFindPost(board *boards.Board, postNum string, user *users.UserRecord) (boards.Post, bool) {
+
+	postId, _ := strconv.Atoi(strings.TrimPrefix(postNum, `#`))
+
+	post, ok := board.GetPost(postId)
+	if !ok {
+		user.SendText(fmt.Sprintf(`There's no post #%s. Type <ansi fg="command">board</ansi> to see what's been posted.`, postNum))
+	}
+
+	return post, ok
+}
+
+// Walks a player through writing a post or reply, then pins it to the board.
+func boardCompose(rest string, board *boards.Board, replyTo int, user *users.UserRecord, room *rooms.Room) (bool, error) {
+
+	c := configs.GetConfig()
+
+	if user.Muted {
+		user.ClearPrompt()
+		user.SendText(`You are <ansi fg="alert-5">MUTED</ansi> and can't post on bulletin boards.`)
+		return true, nil
+	}
+
+	cmdPrompt, isNew := user.StartPrompt(`board`, rest)
+
+	if isNew {
+
+		if len(board.GetPosts()) >= int(c.BoardMaxPosts) {
+			user.ClearPrompt()
+			user.SendText(`The board is full. There's no room to pin anything else on it.`)
+			return true, nil
+		}
+
+		boardClearDraft(user)
+		user.SetTempData(BoardReplyToKey, replyTo)
+
+		if replyTo > 0 {
+			user.SendText(fmt.Sprintf(`You start a reply to post #%d.%s`, replyTo, term.CRLFStr))
+		} else {
+			user.SendText(fmt.Sprintf(`You start writing a post for <ansi fg="yellow-bold">%s</ansi>.%s`, board.Name, term.CRLFStr))
+		}
+	}
+
+	if tmpReplyTo, ok := user.GetTempData(BoardReplyToKey).(int); !ok || tmpReplyTo != replyTo {
+		user.ClearPrompt()
+		return true, nil
+	}
+
+	post := boards.Post{
+		ReplyTo:      replyTo,
+		AuthorUserId: user.UserId,
+		AuthorName:   user.Character.Name,
+	}
+
+	//
+	// Subject?
+	//
+	defaultSubject := []string{}
+	if replyTo > 0 {
+		if original, ok := board.GetPost(replyTo); ok {
+			defaultSubject = append(defaultSubject, `Re: `+strings.TrimPrefix(original.Subject, `Re: `))
+		}
+	}
+
+	question := cmdPrompt.Ask(`Subject?`, []string{}, defaultSubject...)
+	if !question.Done {
+		return true, nil
+	}
+
+	// The filter counts everything it sees, so each answer is only run through it once
+	subject, _ := user.GetTempData(BoardSubjectKey).(string)
+	if subject == `` {
+
+		// Replies keep the subject they were started with unless a new one is given
+		if question.Response == `` && len(defaultSubject) > 0 {
+			subject = defaultSubject[0]
+		} else {
+
+			if question.Response == `` {
+				user.ClearPrompt()
+				boardClearDraft(user)
+				user.SendText(`You decide not to post anything after all.`)
+				return true, nil
+			}
+
+			if len(question.Response) > boardSubjectMaxLength {
+				user.SendText(fmt.Sprintf(`Subjects can't be longer than %d characters.`, boardSubjectMaxLength))
+				question.RejectResponse()
+				return true, nil
+			}
+
+			var ok bool
+			if subject, ok = boardFilter(user, question); !ok {
+				return true, nil
+			}
+		}
+
+		user.SetTempData(BoardSubjectKey, subject)
+	}
+
+	post.Subject = subject
+
+	//
+	// Message?
+	//
+	question = cmdPrompt.AskMultiLine(`Message?`, ``)
+	if !question.Done {
+		return true, nil
+	}
+
+	body, _ := user.GetTempData(BoardBodyKey).(string)
+	if body == `` {
+
+		if question.Response == `` {
+			user.ClearPrompt()
+			boardClearDraft(user)
+			user.SendText(`You decide not to post anything after all.`)
+			return true, nil
+		}
+
+		if len(question.Response) > int(c.BoardMaxPostLength) {
+			user.SendText(fmt.Sprintf(`Posts can't be longer than %d characters.`, c.BoardMaxPostLength))
+			question.RejectResponse()
+			return true, nil
+		}
+
+		var ok bool
+		if body, ok = boardFilter(user, question); !ok {
+			return true, nil
+		}
+
+		user.SetTempData(BoardBodyKey, body)
+	}
+
+	post.Body = body
+	post.Posted = time.Now()
+
+	//
+	// Display preview?
+	//
+	question = cmdPrompt.Ask(`Pin this to the board?`, []string{`Yes`, `No`}, `No`)
+	if !question.Done {
+
+		tplTxt, _ := templates.Process("boards/post", map[string]any{
+			`Post`: post,
+			`Read`: true,
+		})
+		user.SendText(tplTxt)
+
+		return true, nil
+	}
+
+	user.ClearPrompt()
+	boardClearDraft(user)
+
+	if question.Response[0:1] != `Y` {
+		user.SendText(`You crumple up what you wrote.`)
+		return true, nil
+	}
+
+	post, err := board.AddPost(post)
+	if err != nil {
+		switch {
+		case errors.Is(err, boards.ErrBoardFull):
+			user.SendText(`The board filled up while you were writing. There's no room to pin it.`)
+		case errors.Is(err, boards.ErrBadReplyTo):
+			user.SendText(`The post you were replying to has been taken down.`)
+		default:
+			user.SendText(fmt.Sprintf(`Your post could not be pinned to the board: %s`, err))
+		}
+		return true, nil
+	}
+
+	chatlog.Log(chatlog.Entry{
+		Kind:       chatlog.KindBoard,
+		RoomId:     room.RoomId,
+		FromUserId: user.UserId,
+		FromName:   user.Character.Name,
+		Text:       fmt.Sprintf(`[%s #%d] %s: %s`, board.BoardId, post.PostId, post.Subject, post.Body),
+	})
+
+	user.SendText(fmt.Sprintf(`You pin post #%d to the board.`, post.PostId))
+	room.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> pins something to the bulletin board.`, user.Character.Name), user.UserId)
+
+	return true, nil
+}
+
+// Runs an answer through the chat filter, asking for it again if it's turned down.
+func boardFilter(user *users.UserRecord, question *prompt.Question) (string, bool) {
+
+	text, ok := filterText(user, question.Response)
+	if ok {
+		return text, true
+	}
+
+	// Nothing more can be posted once the filter has muted them
+	if user.Muted {
+		user.ClearPrompt()
+		boardClearDraft(user)
+		return ``, false
+	}
+
+	question.RejectResponse()
+
+	return ``, false
+}
+
+// Forgets whatever post was being written.
+func boardClearDraft(user *users.UserRecord) {
+	user.SetTempData(BoardReplyToKey, nil)
+	user.SetTempData(BoardSubjectKey, nil)
+	user.SetTempData(BoardBodyKey, nil)
+}
+
+func boardPlace(args []string, user *users.UserRecord, room *rooms.Room) (bool, error) {
+
+	if len(args) == 0 {
+		user.SendText(`Type <ansi fg="command">board place [board id] [name]</ansi>. Rooms placed with the same board id share its posts.`)
+		return true, nil
+	}
+
+	boardId := strings.ToLower(args[0])
+	name := strings.Join(args[1:], ` `)
+	if name == `` {
+		name = `Bulletin Board`
+	}
+
+	board, err := boards.Create(boardId, name)
+	if err != nil {
+		user.SendText(fmt.Sprintf(`The board could not be placed: %s`, err))
+		return true, nil
+	}
+
+	room.Board = board.BoardId
+	rooms.SaveRoom(*room)
+
+	user.SendText(fmt.Sprintf(`<ansi fg="yellow-bold">%s</ansi> (<ansi fg="yellow">%s</ansi>) has been placed in this room.`, board.Name, board.BoardId))
+	room.SendText(`A bulletin board is put up.`, user.UserId)
+
+	return true, nil
+}
+
+func boardRemove(user *users.UserRecord, room *rooms.Room) (bool, error) {
+
+	if room.Board == `` {
+		user.SendText(`There is no bulletin board here.`)
+		return true, nil
+	}
+
+	boardId := room.Board
+
+	room.Board = ``
+	rooms.SaveRoom(*room)
+
+	user.SendText(fmt.Sprintf(`The bulletin board has been taken down. Its posts are kept, and it can be placed again with <ansi fg="command">board place %s</ansi>.`, boardId))
+	room.SendText(`A bulletin board is taken down.`, user.UserId)
+
+	return true, nil
+}
@@ -8,9 +8,11 @@ import (
 	"github.com/volte6/gomud/buffs"
 	"github.com/volte6/gomud/characters"
 	"github.com/volte6/gomud/events"
+	"github.com/volte6/gomud/factions"
 	"github.com/volte6/gomud/items"
 	"github.com/volte6/gomud/mobs"
 	"github.com/volte6/gomud/pets"
+	"github.com/volte6/gomud/quests"
 	"github.com/volte6/gomud/rooms"
 	"github.com/volte6/gomud/skills"
 	"github.com/volte6/gomud/users"
@@ -113,6 +115,16 @@ func tryPurchase(request string, user *users.UserRecord, room *rooms.Room, shopM
 	petNames := []string{}
 	petPrices := map[string]int{}
 
+	// Prices depend on the merchant's faction
+	standing := factions.StandingNeutral
+	if shopMob != nil {
+		standing, _ = user.Character.GetFactionStanding(shopMob.Groups)
+		if !standing.WillTrade() {
+			shopMob.Command(`say I don't deal with the likes of you.`)
+			return false
+		}
+	}
+
 	var saleItems characters.Shop
 	if shopMob != nil {
 		saleItems = shopMob.Character.Shop.GetInstock()
@@ -251,6 +263,8 @@ func tryPurchase(request string, user *users.UserRecord, room *rooms.Room, shopM
 		price = petPrices[matchedShopItem.PetType]
 	}
 
+	price = standing.BuyPrice(price)
+
 	if user.Character.Gold < price {
 		if shopMob != nil {
 			shopMob.Command(`say You don't have enough gold for that.`)
@@ -306,6 +320,12 @@ func tryPurchase(request string, user *users.UserRecord, room *rooms.Room, shopM
 
 		}
 
+		events.AddToQueue(events.QuestObjective{
+			UserId:        user.UserId,
+			ObjectiveType: string(quests.ObjectiveCollect),
+			ItemId:        newItm.ItemId,
+		})
+
 		if shopMob != nil {
 
 			user.SendText(
@@ -451,6 +471,9 @@ func tryPurchase(request string, user *users.UserRecord, room *rooms.Room, shopM
 		if shopMob != nil {
 			shopMob.Command(fmt.Sprintf(`say Take care of your %s, it will always be loyal to you.`, petInfo.DisplayName()), 1)
 			shopMob.Command(`say You can name your pet with the <ansi fg="command">pet</ansi> command.`, 1)
+			if petInfo.Mountable {
+				shopMob.Command(`say You can ride it with the <ansi fg="command">mount</ansi> command.`, 1)
+			}
 		}
 
 		if user.Character.Pet.Exists() {
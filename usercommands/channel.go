@@ -0,0 +1,172 @@
+package usercommands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/volte6/gomud/channels"
+	"github.com/volte6/gomud/rooms"
+	"github.com/volte6/gomud/templates"
+	"github.com/volte6/gomud/users"
+	"github.com/volte6/gomud/util"
+)
+
+// Lists chat channels, and lets users join, leave or mute them.
+func Channel(rest string, user *users.UserRecord, room *rooms.Room) (bool, error) {
+
+	args := util.SplitButRespectQuotes(strings.ToLower(rest))
+
+	if len(args) == 0 {
+		channelList(user)
+		return true, nil
+	}
+
+	if len(args) < 2 {
+		user.SendText(fmt.Sprintf(`Which channel? Type <ansi fg="command">channel %s [channel]</ansi>.`, args[0]))
+		return true, nil
+	}
+
+	ch := channels.Get(args[1])
+	if ch == nil || ch.CanJoin(user) != nil {
+		user.SendText(fmt.Sprintf(`There is no "%s" channel. Type <ansi fg="command">channel</ansi> to see them all.`, args[1]))
+		return true, nil
+	}
+
+	switch args[0] {
+
+	case `join`, `unmute`:
+
+		if ch.IsListening(user) {
+			user.SendText(fmt.Sprintf(`You are already listening to <ansi fg="%s">%s</ansi>.`, ch.Color, ch.Name))
+			return true, nil
+		}
+
+		user.SetChannelState(ch.ChannelId, users.ChannelJoined)
+		user.SendText(fmt.Sprintf(`You are now listening to <ansi fg="%s">%s</ansi>. Type <ansi fg="command">%s [message]</ansi> to talk.`, ch.Color, ch.Name, ch.ChannelId))
+
+		channelHistory(ch, user)
+		channels.SendGMCPList(user)
+
+	case `leave`:
+
+		if !ch.IsJoined(user) {
+			user.SendText(fmt.Sprintf(`You aren't on <ansi fg="%s">%s</ansi>.`, ch.Color, ch.Name))
+			return true, nil
+		}
+
+		user.SetChannelState(ch.ChannelId, users.ChannelLeft)
+		user.SendText(fmt.Sprintf(`You leave <ansi fg="%s">%s</ansi>.`, ch.Color, ch.Name))
+
+		channels.SendGMCPList(user)
+
+	case `mute`:
+
+		if !ch.IsListening(user) {
+			user.SendText(fmt.Sprintf(`You aren't listening to <ansi fg="%s">%s</ansi>.`, ch.Color, ch.Name))
+			return true, nil
+		}
+
+		user.SetChannelState(ch.ChannelId, users.ChannelMuted)
+		user.SendText(fmt.Sprintf(`You mute <ansi fg="%s">%s</ansi>. Type <ansi fg="command">channel unmute %s</ansi> to hear it again.`, ch.Color, ch.Name, ch.ChannelId))
+
+	case `history`:
+
+		channelHistory(ch, user)
+
+	default:
+		user.SendText(`Try <ansi fg="command">help channel</ansi> to see what you can do with channels.`)
+	}
+
+	return true, nil
+}
+
+func channelList(user *users.UserRecord) {
+
+	headers := []string{`Channel`, `Status`, `Description`}
+	rows := [][]string{}
+	formatting := [][]string{}
+
+	for _, ch := range channels.GetAll() {
+
+		if ch.CanJoin(user) != nil {
+			continue
+		}
+
+		status := user.GetChannelState(ch.ChannelId, ch.AutoJoin)
+		statusColor := `green`
+		if status == users.ChannelLeft {
+			statusColor = `black-bold`
+		} else if status == users.ChannelMuted {
+			statusColor = `yellow`
+		}
+
+		rows = append(rows, []string{ch.ChannelId, status, ch.Description})
+		formatting = append(formatting, []string{
+			`<ansi fg="` + ch.Color + `">%s</ansi>`,
+			`<ansi fg="` + statusColor + `">%s</ansi>`,
+			`%s`,
+		})
+	}
+
+	channelTableData := templates.GetTable(`Chat Channels`, headers, rows, formatting...)
+	channelTxt, _ := templates.Process("tables/generic", channelTableData)
+	user.SendText(channelTxt)
+
+	user.SendText(`Type <ansi fg="command">[channel] [message]</ansi> to talk, or <ansi fg="command">help channel</ansi> for more.`)
+
+	channels.SendGMCPList(user)
+}
+
+func channelHistory(ch *channels.Channel, user *users.UserRecord) {
+
+	recent := ch.GetHistory(user)
+	if len(recent) == 0 {
+		return
+	}
+
+	user.SendText(fmt.Sprintf(`<ansi fg="black-bold">Recently on %s:</ansi>`, ch.Name))
+	for _, entry := range recent {
+		user.SendText(fmt.Sprintf(`<ansi fg="black-bold">[%s]</ansi> %s`, entry.When.Format(`15:04`), entry.Text))
+	}
+}
+
+// Talks on a channel, or shows its history if there is nothing to say
+func channelTalk(ch *channels.Channel, rest string, user *users.UserRecord) (bool, error) {
+
+	if ch == nil || ch.CanJoin(user) != nil {
+		return false, nil
+	}
+
+	if rest == `` {
+		if len(ch.GetHistory(user)) == 0 {
+			user.SendText(fmt.Sprintf(`Nothing has been said on %s lately. Type <ansi fg="command">%s [message]</ansi> to talk.`, ch.Name, ch.ChannelId))
+			return true, nil
+		}
+		channelHistory(ch, user)
+		return true, nil
+	}
+
+	// Check before filtering, so muted players can't rack up strikes
+	if user.IsMutedOn(ch.ChannelId) {
+		user.SendText(`You are <ansi fg="alert-5">MUTED</ansi>. You can only send <ansi fg="command">whisper</ansi>'s to Admins and Moderators.`)
+		return true, nil
+	}
+
+	var ok bool
+	if rest, ok = filterText(user, rest); !ok {
+		return true, nil
+	}
+
+	switch ch.Send(user, rest) {
+	case channels.ErrNotJoined:
+		user.SendText(fmt.Sprintf(`You aren't on <ansi fg="%s">%s</ansi>. Type <ansi fg="command">channel join %s</ansi> first.`, ch.Color, ch.Name, ch.ChannelId))
+	case channels.ErrMuted:
+		user.SendText(`You are <ansi fg="alert-5">MUTED</ansi>. You can only send <ansi fg="command">whisper</ansi>'s to Admins and Moderators.`)
+	case channels.ErrLevelTooLow:
+		user.SendText(fmt.Sprintf(`You must be level %d to talk on %s.`, ch.MinLevel, ch.Name))
+	case channels.ErrRateLimited:
+		user.SendText(fmt.Sprintf(`You're talking on %s too often. Wait a moment.`, ch.Name))
+	}
+
+	return true, nil
+}
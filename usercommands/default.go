@@ -33,6 +33,35 @@ func Default(rest string, user *users.UserRecord, room *rooms.Room) (bool, error
 		return true, nil
 	}
 
+	// If a stable, "stable"
+	if room.IsStable {
+		Stable(``, user, room)
+		return true, nil
+	}
+
+	// If a post office, "mail"
+	if room.IsPostOffice {
+		Mail(``, user, room)
+		return true, nil
+	}
+
+	// If a quest board, "questboard"
+	if room.IsQuestBoard() {
+		Questboard(``, user, room)
+		return true, nil
+	}
+
+	if room.Board != `` {
+		Board(``, user, room)
+		return true, nil
+	}
+
+	// If an arena, "ladder"
+	if room.IsArena {
+		Ladder(``, user, room)
+		return true, nil
+	}
+
 	// Default to "look"
 	Look(``, user, room)
 
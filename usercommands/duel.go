@@ -0,0 +1,143 @@
+package usercommands
+
+import (
+	"fmt"
+
+	"github.com/volte6/gomud/characters"
+	"github.com/volte6/gomud/rooms"
+	"github.com/volte6/gomud/users"
+)
+
+const (
+	DuelChallengeKey = `duel-challenge` // userId of whoever challenged this user
+	DuelOpponentKey  = `duel-opponent`  // userId of the opponent in a duel underway
+)
+
+func Duel(rest string, user *users.UserRecord, room *rooms.Room) (bool, error) {
+
+	if !room.IsArena {
+		user.SendText(`You can only duel in an arena.`)
+		return true, nil
+	}
+
+	if rest == `` {
+		user.SendText(`Duel who?`)
+		return true, nil
+	}
+
+	if getDuelOpponent(user) != nil {
+		user.SendText(`You are already in a duel!`)
+		return true, nil
+	}
+
+	// Answering a challenge
+	if rest == `accept` {
+
+		challengerId, _ := user.GetTempData(DuelChallengeKey).(int)
+		if challengerId == 0 {
+			user.SendText(`Nobody has challenged you to a duel.`)
+			return true, nil
+		}
+
+		challenger := users.GetByUserId(challengerId)
+		if challenger == nil || challenger.Character.RoomId != room.RoomId {
+			user.SetTempData(DuelChallengeKey, nil)
+			user.ClearPrompt()
+			user.SendText(`Your challenger is no longer here.`)
+			return true, nil
+		}
+
+		cmdPrompt, _ := user.StartPrompt(`duel`, rest)
+		question := cmdPrompt.Ask(fmt.Sprintf(`Accept the duel with %s?`, challenger.Character.Name), []string{`Yes`, `No`}, `No`)
+		if !question.Done {
+			return true, nil
+		}
+
+		user.ClearPrompt()
+		user.SetTempData(DuelChallengeKey, nil)
+
+		if question.Response != `Yes` {
+			user.SendText(fmt.Sprintf(`You decline the duel with <ansi fg="username">%s</ansi>.`, challenger.Character.Name))
+			challenger.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> declines your challenge.`, user.Character.Name))
+			return true, nil
+		}
+
+		if getDuelOpponent(challenger) != nil || challenger.Character.Aggro != nil {
+			user.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> is busy fighting someone else.`, challenger.Character.Name))
+			return true, nil
+		}
+
+		user.SetTempData(DuelOpponentKey, challenger.UserId)
+		challenger.SetTempData(DuelOpponentKey, user.UserId)
+
+		user.Character.SetAggro(challenger.UserId, 0, characters.DefaultAttack)
+		challenger.Character.SetAggro(user.UserId, 0, characters.DefaultAttack)
+
+		user.SendText(fmt.Sprintf(`You accept the challenge and square off against <ansi fg="username">%s</ansi>!`, challenger.Character.Name))
+		challenger.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> accepts your challenge and squares off against you!`, user.Character.Name))
+		room.SendText(fmt.Sprintf(`<ansi fg="yellow-bold">The duel between <ansi fg="username">%s</ansi> and <ansi fg="username">%s</ansi> has begun!</ansi>`, challenger.Character.Name, user.Character.Name), user.UserId, challenger.UserId)
+
+		return true, nil
+	}
+
+	// Issuing a challenge
+	playerId, _ := room.FindByName(rest, rooms.FindAll)
+	if playerId == 0 {
+		user.SendText(fmt.Sprintf(`"%s" isn't here.`, rest))
+		return true, nil
+	}
+
+	if playerId == user.UserId {
+		user.SendText(`You can't duel yourself.`)
+		return true, nil
+	}
+
+	target := users.GetByUserId(playerId)
+	if target == nil {
+		user.SendText(fmt.Sprintf(`"%s" isn't here.`, rest))
+		return true, nil
+	}
+
+	if getDuelOpponent(target) != nil || target.Character.Aggro != nil {
+		user.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> is busy fighting someone else.`, target.Character.Name))
+		return true, nil
+	}
+
+	if target.GetPrompt() != nil {
+		user.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> is busy. Try again in a moment.`, target.Character.Name))
+		return true, nil
+	}
+
+	target.SetTempData(DuelChallengeKey, user.UserId)
+
+	// The prompt is answered by the target, and brings them back here to accept
+	cmdPrompt, _ := target.StartPrompt(`duel`, `accept`)
+	cmdPrompt.Ask(fmt.Sprintf(`Accept the duel with %s?`, user.Character.Name), []string{`Yes`, `No`}, `No`)
+
+	user.SendText(fmt.Sprintf(`You challenge <ansi fg="username">%s</ansi> to a duel. (Rating: <ansi fg="yellow">%d</ansi>)`, target.Character.Name, target.Character.PvP.GetRating()))
+	target.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> challenges you to a duel! (Rating: <ansi fg="yellow">%d</ansi>)`, user.Character.Name, user.Character.PvP.GetRating()))
+	room.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> challenges <ansi fg="username">%s</ansi> to a duel!`, user.Character.Name, target.Character.Name), user.UserId, target.UserId)
+
+	return true, nil
+}
+
+// Returns the opponent of a duel underway, if any.
+// Duels that were abandoned (someone left or logged off) are cleared out.
+func getDuelOpponent(user *users.UserRecord) *users.UserRecord {
+
+	opponentId, _ := user.GetTempData(DuelOpponentKey).(int)
+	if opponentId == 0 {
+		return nil
+	}
+
+	if opponent := users.GetByUserId(opponentId); opponent != nil {
+		if opponent.Character.RoomId == user.Character.RoomId {
+			return opponent
+		}
+		opponent.SetTempData(DuelOpponentKey, nil)
+	}
+
+	user.SetTempData(DuelOpponentKey, nil)
+
+	return nil
+}
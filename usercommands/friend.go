@@ -0,0 +1,124 @@
+package usercommands
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/volte6/gomud/configs"
+	"github.com/volte6/gomud/rooms"
+	"github.com/volte6/gomud/templates"
+	"github.com/volte6/gomud/users"
+)
+
+// Keeps a list of friends, who the user is told about when they log in or out.
+func Friend(rest string, user *users.UserRecord, room *rooms.Room) (bool, error) {
+
+	if rest == `` {
+		friendList(user)
+		return true, nil
+	}
+
+	cmd, name, _ := strings.Cut(rest, ` `)
+	cmd = strings.ToLower(cmd)
+
+	if cmd != `add` && cmd != `remove` {
+		cmd, name = `add`, rest
+	}
+
+	name = strings.TrimSpace(name)
+	if name == `` {
+		user.SendText(fmt.Sprintf(`Type <ansi fg="command">friend %s [player]</ansi>.`, cmd))
+		return true, nil
+	}
+
+	friend := findPlayer(name)
+	if friend == nil {
+		user.SendText(fmt.Sprintf(`There's nobody named %s.`, name))
+		return true, nil
+	}
+
+	if friend.UserId == user.UserId {
+		user.SendText(`You are already your own best friend.`)
+		return true, nil
+	}
+
+	if cmd == `remove` {
+
+		if !user.RemoveFriend(friend.UserId) {
+			user.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> isn't on your friends list.`, friend.Character.Name))
+			return true, nil
+		}
+
+		user.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> has been removed from your friends list.`, friend.Character.Name))
+		return true, nil
+	}
+
+	if !user.AddFriend(friend.UserId) {
+		user.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> is already on your friends list.`, friend.Character.Name))
+		return true, nil
+	}
+
+	user.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> has been added to your friends list. You'll be told when they log in or out.`, friend.Character.Name))
+
+	return true, nil
+}
+
+func friendList(user *users.UserRecord) {
+
+	if len(user.Friends) == 0 {
+		user.SendText(`You haven't added any friends. Type <ansi fg="command">friend [player]</ansi> to add one.`)
+		return
+	}
+
+	online := []*users.UserRecord{}
+	offline := []*users.UserRecord{}
+
+	wanted := map[int]struct{}{}
+	for _, friendId := range user.Friends {
+		if u := users.GetByUserId(friendId); u != nil {
+			online = append(online, u)
+		} else {
+			wanted[friendId] = struct{}{}
+		}
+	}
+
+	if len(wanted) > 0 {
+		users.SearchOfflineUsers(func(u *users.UserRecord) bool {
+			if _, ok := wanted[u.UserId]; ok {
+				offline = append(offline, u)
+				delete(wanted, u.UserId)
+			}
+			return len(wanted) > 0
+		})
+	}
+
+	// Most recently seen first
+	sort.Slice(offline, func(i, j int) bool {
+		return offline[i].LastSeen.After(offline[j].LastSeen)
+	})
+
+	tFormat := string(configs.GetConfig().TimeFormat)
+
+	headers := []string{`Name`, `Status`, `Last Seen`}
+	rows := [][]string{}
+	formatting := [][]string{}
+
+	for _, u := range online {
+		rows = append(rows, []string{u.Character.Name, `online`, `now`})
+		formatting = append(formatting, []string{`<ansi fg="username">%s</ansi>`, `<ansi fg="green">%s</ansi>`, `%s`})
+	}
+
+	for _, u := range offline {
+		lastSeen := `unknown`
+		if !u.LastSeen.IsZero() {
+			lastSeen = u.LastSeen.Format(tFormat)
+		}
+		rows = append(rows, []string{u.Character.Name, `offline`, lastSeen})
+		formatting = append(formatting, []string{`<ansi fg="username">%s</ansi>`, `<ansi fg="black-bold">%s</ansi>`, `%s`})
+	}
+
+	friendTableData := templates.GetTable(`Friends`, headers, rows, formatting...)
+	friendTxt, _ := templates.Process("tables/generic", friendTableData)
+	user.SendText(friendTxt)
+}
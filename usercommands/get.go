@@ -7,6 +7,7 @@ import (
 	"github.com/volte6/gomud/buffs"
 	"github.com/volte6/gomud/events"
 	"github.com/volte6/gomud/items"
+	"github.com/volte6/gomud/quests"
 	"github.com/volte6/gomud/rooms"
 	"github.com/volte6/gomud/scripting"
 	"github.com/volte6/gomud/users"
@@ -126,6 +127,12 @@ func Get(rest string, user *users.UserRecord, room *rooms.Room) (bool, error) {
 
 	}
 
+	// Only the owner can take things out of a home
+	if room.IsHome() && !room.Home.IsOwner(user.UserId) && user.Permission != users.PermissionAdmin {
+		user.SendText(fmt.Sprintf(`Everything here belongs to <ansi fg="username">%s</ansi>. Best leave it be.`, room.Home.OwnerName))
+		return true, nil
+	}
+
 	if containerName != `` {
 		container := room.Containers[containerName]
 
@@ -180,6 +187,12 @@ func Get(rest string, user *users.UserRecord, room *rooms.Room) (bool, error) {
 
 				}
 
+				events.AddToQueue(events.QuestObjective{
+					UserId:        user.UserId,
+					ObjectiveType: string(quests.ObjectiveCollect),
+					ItemId:        matchItem.ItemId,
+				})
+
 				user.SendText(
 					fmt.Sprintf(`You take the <ansi fg="itemname">%s</ansi> from the <ansi fg="container">%s</ansi>.`, matchItem.DisplayName(), containerName),
 				)
@@ -267,6 +280,12 @@ func Get(rest string, user *users.UserRecord, room *rooms.Room) (bool, error) {
 
 				}
 
+				events.AddToQueue(events.QuestObjective{
+					UserId:        user.UserId,
+					ObjectiveType: string(quests.ObjectiveCollect),
+					ItemId:        matchItem.ItemId,
+				})
+
 				if getFromStash {
 					user.SendText(
 						fmt.Sprintf(`You dig out the <ansi fg="itemname">%s</ansi> from where it was stashed.`, matchItem.DisplayName()),
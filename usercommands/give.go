@@ -9,6 +9,7 @@ import (
 	"github.com/volte6/gomud/events"
 	"github.com/volte6/gomud/items"
 	"github.com/volte6/gomud/mobs"
+	"github.com/volte6/gomud/quests"
 	"github.com/volte6/gomud/rooms"
 	"github.com/volte6/gomud/scripting"
 	"github.com/volte6/gomud/users"
@@ -85,6 +86,12 @@ func Give(rest string, user *users.UserRecord, room *rooms.Room) (bool, error) {
 
 			}
 
+			events.AddToQueue(events.QuestObjective{
+				UserId:        targetUser.UserId,
+				ObjectiveType: string(quests.ObjectiveCollect),
+				ItemId:        giveItem.ItemId,
+			})
+
 			user.SendText(
 				fmt.Sprintf(`You give the <ansi fg="item">%s</ansi> to <ansi fg="username">%s</ansi>.`, giveItem.DisplayName(), targetUser.Character.Name),
 			)
@@ -176,6 +183,13 @@ func Give(rest string, user *users.UserRecord, room *rooms.Room) (bool, error) {
 					// Trigger onLost event
 					scripting.TryItemScriptEvent(`onLost`, giveItem, user.UserId)
 
+					events.AddToQueue(events.QuestObjective{
+						UserId:        user.UserId,
+						ObjectiveType: string(quests.ObjectiveDeliver),
+						MobId:         int(m.MobId),
+						ItemId:        giveItem.ItemId,
+					})
+
 				}
 
 				if handled, err := scripting.TryMobScriptEvent(`onGive`, m.InstanceId, user.UserId, `user`, map[string]any{`gold`: giveGoldAmount, `item`: giveItem}); err == nil {
@@ -47,6 +47,11 @@ func Go(rest string, user *users.UserRecord, room *rooms.Room) (bool, error) {
 			encumbered = true
 		}
 
+		// Mounts carry you further for less effort
+		if user.Character.Pet.IsRidden() {
+			actionCost -= actionCost * user.Character.Pet.MoveDiscount / 100
+		}
+
 		if !user.Character.DeductActionPoints(actionCost) {
 
 			if encumbered {
@@ -132,6 +137,42 @@ func Go(rest string, user *users.UserRecord, room *rooms.Room) (bool, error) {
 			return false, fmt.Errorf(`room %d not found`, goRoomId)
 		}
 
+		if !destRoom.CanEnter(user) {
+			user.SendText(`That is a private home. You'll need the owner's permission to go in.`)
+			return true, nil
+		}
+
+		if destRoom.IsIndoors && user.Character.Pet.IsRidden() {
+			user.SendText(fmt.Sprintf(`You can't ride %s in there. You'll need to <ansi fg="command">dismount</ansi> first.`, user.Character.Pet.DisplayName()))
+			return true, nil
+		}
+
+		// Some biomes (such as deep water) can't be entered without the right equipment
+		destBiome := destRoom.GetBiome()
+		if requiredItemId := destBiome.RequiredItemId(); requiredItemId > 0 {
+
+			requiredItm := items.Item{}
+			for _, itm := range append(user.Character.GetAllBackpackItems(), user.Character.GetAllWornItems()...) {
+				if itm.ItemId == requiredItemId {
+					requiredItm = itm
+					break
+				}
+			}
+
+			if requiredItm.ItemId == 0 {
+				if iSpec := items.GetItemSpec(requiredItemId); iSpec != nil {
+					user.SendText(fmt.Sprintf(`You can't go that way without a <ansi fg="item">%s</ansi>.`, iSpec.Name))
+				} else {
+					user.SendText(`You can't go that way.`)
+				}
+				return true, nil
+			}
+
+			if destBiome.UsesItem() {
+				user.Character.UseItem(requiredItm)
+			}
+		}
+
 		// Grab the exit in the target room that leads to this room (if any)
 		enterFromExit := destRoom.FindExitTo(room.RoomId)
 
@@ -164,13 +205,28 @@ func Go(rest string, user *users.UserRecord, room *rooms.Room) (bool, error) {
 						fmt.Sprintf(`You <ansi fg="black-bold">sneak</ansi> towards the <ansi fg="exit">%s</ansi> exit.`, exitName),
 					))
 			} else {
-				user.SendText(
-					fmt.Sprintf(string(c.ExitRoomMessageWrapper),
-						fmt.Sprintf(`You head towards the <ansi fg="exit">%s</ansi> exit.`, exitName),
-					))
+				if user.Character.Pet.IsRidden() {
+					user.SendText(
+						fmt.Sprintf(string(c.ExitRoomMessageWrapper),
+							fmt.Sprintf(`You ride %s towards the <ansi fg="exit">%s</ansi> exit.`, user.Character.Pet.DisplayName(), exitName),
+						))
+				} else {
+					user.SendText(
+						fmt.Sprintf(string(c.ExitRoomMessageWrapper),
+							fmt.Sprintf(`You head towards the <ansi fg="exit">%s</ansi> exit.`, exitName),
+						))
+				}
 
 				// Tell the old room they are leaving
-				if user.Character.Pet.Exists() {
+				if user.Character.Pet.IsRidden() {
+
+					room.SendText(
+						fmt.Sprintf(string(c.ExitRoomMessageWrapper),
+							fmt.Sprintf(`<ansi fg="username">%s</ansi> rides %s towards the <ansi fg="exit">%s</ansi> exit.`, user.Character.Name, user.Character.Pet.DisplayName(), exitName),
+						),
+						user.UserId)
+
+				} else if user.Character.Pet.Exists() {
 
 					room.SendText(
 						fmt.Sprintf(string(c.ExitRoomMessageWrapper),
@@ -187,7 +243,15 @@ func Go(rest string, user *users.UserRecord, room *rooms.Room) (bool, error) {
 				}
 
 				// Tell everyone if the pet is following
-				if user.Character.Pet.Exists() {
+				if user.Character.Pet.IsRidden() {
+
+					destRoom.SendText(
+						fmt.Sprintf(string(c.EnterRoomMessageWrapper),
+							fmt.Sprintf(`<ansi fg="username">%s</ansi> rides in on %s from %s.`, user.Character.Name, user.Character.Pet.DisplayName(), enterFromExit),
+						),
+						user.UserId)
+
+				} else if user.Character.Pet.Exists() {
 
 					user.SendText(fmt.Sprintf(`%s follows you.`, user.Character.Pet.DisplayName()))
 
@@ -295,7 +359,7 @@ func Go(rest string, user *users.UserRecord, room *rooms.Room) (bool, error) {
 						continue
 					}
 
-					isHostile := mob.Hostile // Is it automatically hostile?
+					isHostile := mob.IsHostileToward(user.Character) // Is it automatically hostile?
 					if !isHostile {
 						for _, groupName := range mob.Groups {
 							if mobs.IsHostile(groupName, user.UserId) {
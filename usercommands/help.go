@@ -9,6 +9,7 @@ import (
 	"github.com/volte6/gomud/keywords"
 	"github.com/volte6/gomud/races"
 	"github.com/volte6/gomud/rooms"
+	"github.com/volte6/gomud/socials"
 	"github.com/volte6/gomud/spells"
 	"github.com/volte6/gomud/templates"
 	"github.com/volte6/gomud/users"
@@ -93,8 +94,8 @@ func Help(rest string, user *users.UserRecord, room *rooms.Room) (bool, error) {
 
 		var helpVars any = nil
 
-		if helpName == `emote` {
-			helpVars = emoteAliases
+		if helpName == `socials` {
+			helpVars = socials.Names()
 		}
 
 		if helpName == `races` {
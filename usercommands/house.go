@@ -0,0 +1,235 @@
+package usercommands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/volte6/gomud/configs"
+	"github.com/volte6/gomud/gametime"
+	"github.com/volte6/gomud/items"
+	"github.com/volte6/gomud/rooms"
+	"github.com/volte6/gomud/term"
+	"github.com/volte6/gomud/users"
+	"github.com/volte6/gomud/util"
+)
+
+const (
+	houseTitleMaxLength       = 40
+	houseDescriptionMaxLength = 1024
+)
+
+func House(rest string, user *users.UserRecord, room *rooms.Room) (bool, error) {
+
+	args := util.SplitButRespectQuotes(strings.ToLower(rest))
+
+	homeRoomId := rooms.GetHomeRoomId(user.UserId)
+
+	if len(args) == 0 {
+
+		if homeRoomId == 0 {
+			user.SendText(`You don't own a home. Buy a <ansi fg="item">deed</ansi> and visit a housing district to build one.`)
+			user.SendText(`Try <ansi fg="command">help house</ansi> for more information.`)
+			return true, nil
+		}
+
+		homeRoom := rooms.LoadRoom(homeRoomId)
+		if homeRoom == nil || homeRoom.Home == nil {
+			return false, fmt.Errorf(`room %d not found`, homeRoomId)
+		}
+
+		c := configs.GetConfig()
+		dueDate := gametime.GetDate(homeRoom.Home.RentDueRound)
+
+		user.SendText(``)
+		user.SendText(fmt.Sprintf(`<ansi fg="yellow-bold">%s</ansi>`, homeRoom.Title))
+		user.SendText(fmt.Sprintf(`  Entrance: <ansi fg="exit">%s</ansi>`, homeRoom.Home.DistrictExit))
+		user.SendText(fmt.Sprintf(`  Rent:     <ansi fg="gold">%d gold</ansi> every %s, collected from your bank`, c.HousingRent, c.HousingRentPeriod))
+		user.SendText(fmt.Sprintf(`  Next Due: <ansi fg="230">day %d</ansi> of <ansi fg="230">year %d</ansi>`, dueDate.Day, dueDate.Year))
+		if homeRoom.Home.MissedPayments > 0 {
+			user.SendText(fmt.Sprintf(`  Missed:   <ansi fg="red">%d/%d</ansi> payments`, homeRoom.Home.MissedPayments, c.HousingMissedRentLimit))
+		}
+
+		guestNames := homeRoom.Home.GuestNames()
+		if len(guestNames) == 0 {
+			user.SendText(`  Guests:   none`)
+		} else {
+			user.SendText(fmt.Sprintf(`  Guests:   <ansi fg="username">%s</ansi>`, strings.Join(guestNames, `</ansi>, <ansi fg="username">`)))
+		}
+		user.SendText(``)
+
+		return true, nil
+	}
+
+	action := args[0]
+
+	if action == `build` {
+
+		if !room.IsHousingDistrict {
+			user.SendText(`You can only build a home in a housing district.`)
+			return true, nil
+		}
+
+		if homeRoomId > 0 {
+			user.SendText(`You already own a home.`)
+			return true, nil
+		}
+
+		var deedItem items.Item
+		for _, itm := range user.Character.GetAllBackpackItems() {
+			if itm.GetSpec().Type == items.Deed {
+				deedItem = itm
+				break
+			}
+		}
+
+		if deedItem.ItemId == 0 {
+			user.SendText(`You need a <ansi fg="item">deed</ansi> to build a home.`)
+			return true, nil
+		}
+
+		homeRoom, err := rooms.BuildHome(room.RoomId, user)
+		if err != nil {
+			user.SendText(`Something went wrong building your home.`)
+			return true, err
+		}
+
+		user.Character.RemoveItem(deedItem)
+
+		user.SendText(fmt.Sprintf(`You hand over your <ansi fg="item">%s</ansi>. Congratulations on your new home! You can reach it through the <ansi fg="exit">%s</ansi> exit.`, deedItem.DisplayName(), homeRoom.Home.DistrictExit))
+		room.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> has moved into the neighborhood.`, user.Character.Name), user.UserId)
+
+		return true, nil
+	}
+
+	if homeRoomId == 0 {
+		user.SendText(`You don't own a home.`)
+		return true, nil
+	}
+
+	homeRoom := rooms.LoadRoom(homeRoomId)
+	if homeRoom == nil || homeRoom.Home == nil {
+		return false, fmt.Errorf(`room %d not found`, homeRoomId)
+	}
+
+	switch action {
+
+	case `guests`:
+
+		guestNames := homeRoom.Home.GuestNames()
+		if len(guestNames) == 0 {
+			user.SendText(`No one else is allowed into your home.`)
+		} else {
+			user.SendText(fmt.Sprintf(`Allowed into your home: <ansi fg="username">%s</ansi>`, strings.Join(guestNames, `</ansi>, <ansi fg="username">`)))
+		}
+
+	case `allow`:
+
+		if len(args) < 2 {
+			user.SendText(`Allow who?`)
+			return true, nil
+		}
+
+		guest := users.GetByCharacterName(args[1])
+		if guest == nil {
+			user.SendText(fmt.Sprintf(`"%s" isn't online.`, args[1]))
+			return true, nil
+		}
+
+		if guest.UserId == user.UserId {
+			user.SendText(`You already have access to your own home.`)
+			return true, nil
+		}
+
+		homeRoom.Home.AddGuest(guest.UserId, guest.Character.Name)
+		rooms.SaveRoom(*homeRoom)
+
+		user.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> can now enter your home.`, guest.Character.Name))
+		guest.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> has given you access to their home.`, user.Character.Name))
+
+	case `deny`:
+
+		if len(args) < 2 {
+			user.SendText(`Deny who?`)
+			return true, nil
+		}
+
+		guestUserId := homeRoom.Home.RemoveGuest(args[1])
+		if guestUserId == 0 {
+			user.SendText(fmt.Sprintf(`"%s" isn't on your guest list.`, args[1]))
+			return true, nil
+		}
+
+		rooms.SaveRoom(*homeRoom)
+
+		user.SendText(fmt.Sprintf(`%s can no longer enter your home.`, args[1]))
+
+		if guest := users.GetByUserId(guestUserId); guest != nil {
+			guest.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> has taken away your access to their home.`, user.Character.Name))
+			if guest.Character.RoomId == homeRoom.RoomId {
+				guest.SendText(`You are shown the door.`)
+				rooms.MoveToRoom(guest.UserId, homeRoom.Home.DistrictRoomId)
+			}
+		}
+
+	case `title`, `describe`:
+
+		if room.RoomId != homeRoom.RoomId {
+			user.SendText(`You need to be in your home to do that.`)
+			return true, nil
+		}
+
+		// Use the original text to preserve capitalization
+		text := strings.TrimSpace(rest[len(action):])
+
+		if text == `` {
+			user.SendText(fmt.Sprintf(`What should the %s be?`, action))
+			return true, nil
+		}
+
+		if action == `title` {
+
+			if len(text) > houseTitleMaxLength {
+				user.SendText(fmt.Sprintf(`The title can be at most %d characters long.`, houseTitleMaxLength))
+				return true, nil
+			}
+			homeRoom.Title = text
+
+		} else {
+
+			if len(text) > houseDescriptionMaxLength {
+				user.SendText(fmt.Sprintf(`The description can be at most %d characters long.`, houseDescriptionMaxLength))
+				return true, nil
+			}
+			homeRoom.Description = text
+
+		}
+
+		rooms.SaveRoom(*homeRoom)
+
+		user.SendText(fmt.Sprintf(`You update the %s of your home.`, action))
+
+	case `abandon`:
+
+		cmdPrompt, _ := user.StartPrompt(`house`, rest)
+		questionConfirm := cmdPrompt.Ask(`Abandon your home? Anything inside will be moved to your item storage and bank.`, []string{`Yes`, `No`}, `No`)
+		if !questionConfirm.Done {
+			return true, nil
+		}
+
+		user.ClearPrompt()
+
+		if questionConfirm.Response != `Yes` {
+			user.SendText(`You decide to keep your home.`)
+			return true, nil
+		}
+
+		if err := rooms.EvictHome(homeRoom.RoomId); err != nil {
+			return true, err
+		}
+
+	default:
+		user.SendText(`Try <ansi fg="command">help house</ansi> for more information about your home.` + term.CRLFStr)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,97 @@
+package usercommands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/volte6/gomud/rooms"
+	"github.com/volte6/gomud/users"
+)
+
+// Keeps a list of players whose communications the user doesn't want to receive.
+func Ignore(rest string, user *users.UserRecord, room *rooms.Room) (bool, error) {
+
+	if rest == `` {
+		ignoreList(user)
+		return true, nil
+	}
+
+	cmd, name, _ := strings.Cut(rest, ` `)
+	cmd = strings.ToLower(cmd)
+
+	if cmd != `add` && cmd != `remove` {
+		cmd, name = `add`, rest
+	}
+
+	name = strings.TrimSpace(name)
+	if name == `` {
+		user.SendText(fmt.Sprintf(`Type <ansi fg="command">ignore %s [player]</ansi>.`, cmd))
+		return true, nil
+	}
+
+	ignored := findPlayer(name)
+	if ignored == nil {
+		user.SendText(fmt.Sprintf(`There's nobody named %s.`, name))
+		return true, nil
+	}
+
+	if ignored.UserId == user.UserId {
+		user.SendText(`You can't ignore yourself.`)
+		return true, nil
+	}
+
+	if cmd == `remove` {
+
+		if !user.Unignore(ignored.UserId) {
+			user.SendText(fmt.Sprintf(`You aren't ignoring <ansi fg="username">%s</ansi>.`, ignored.Character.Name))
+			return true, nil
+		}
+
+		user.SendText(fmt.Sprintf(`You are no longer ignoring <ansi fg="username">%s</ansi>.`, ignored.Character.Name))
+		return true, nil
+	}
+
+	if !user.Ignore(ignored.UserId) {
+		user.SendText(fmt.Sprintf(`You are already ignoring <ansi fg="username">%s</ansi>.`, ignored.Character.Name))
+		return true, nil
+	}
+
+	user.SendText(fmt.Sprintf(`You are now ignoring <ansi fg="username">%s</ansi>. You won't hear from them.`, ignored.Character.Name))
+
+	return true, nil
+}
+
+func ignoreList(user *users.UserRecord) {
+
+	if len(user.Ignoring) == 0 {
+		user.SendText(`You aren't ignoring anyone.`)
+		return
+	}
+
+	names := []string{}
+	wanted := map[int]struct{}{}
+
+	for _, ignoredId := range user.Ignoring {
+		if u := users.GetByUserId(ignoredId); u != nil {
+			names = append(names, u.Character.Name)
+		} else {
+			wanted[ignoredId] = struct{}{}
+		}
+	}
+
+	if len(wanted) > 0 {
+		users.SearchOfflineUsers(func(u *users.UserRecord) bool {
+			if _, ok := wanted[u.UserId]; ok {
+				names = append(names, u.Character.Name)
+				delete(wanted, u.UserId)
+			}
+			return len(wanted) > 0
+		})
+	}
+
+	user.SendText(`You are ignoring:`)
+	for _, name := range names {
+		user.SendText(fmt.Sprintf(`  <ansi fg="username">%s</ansi>`, name))
+	}
+	user.SendText(`Type <ansi fg="command">ignore remove [player]</ansi> to hear from someone again.`)
+}
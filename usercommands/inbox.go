@@ -12,6 +12,11 @@ import (
 func Inbox(rest string, user *users.UserRecord, room *rooms.Room) (bool, error) {
 
 	if rest == `clear` {
+		for _, msg := range user.Inbox {
+			if !msg.Archived && msg.IsCODPending() {
+				returnToSender(msg)
+			}
+		}
 		user.Inbox.Empty()
 	}
 
@@ -31,6 +36,10 @@ func Inbox(rest string, user *users.UserRecord, room *rooms.Room) (bool, error)
 
 	for idx, msg := range user.Inbox {
 
+		if msg.Archived {
+			continue
+		}
+
 		if rest == `old` {
 			if !msg.Read {
 				continue
@@ -44,13 +53,8 @@ func Inbox(rest string, user *users.UserRecord, room *rooms.Room) (bool, error)
 
 		user.SendText(border)
 
-		if !msg.Read {
-			if msg.Gold > 0 {
-				user.Character.Bank += msg.Gold
-			}
-			if msg.Item != nil {
-				user.Character.StoreItem(*msg.Item)
-			}
+		if !msg.Read && !msg.IsCODPending() {
+			collectAttachments(user, msg)
 		}
 
 		user.Inbox[idx].Read = true
@@ -59,6 +63,7 @@ func Inbox(rest string, user *users.UserRecord, room *rooms.Room) (bool, error)
 	user.SendText(``)
 	user.SendText(`<ansi fg="159">Type <ansi fg="command">inbox old</ansi> to read old messages.</ansi>`)
 	user.SendText(`<ansi fg="159">Type <ansi fg="command">inbox clear</ansi> to clear all messages in your inbox.</ansi>`)
+	user.SendText(`<ansi fg="159">Type <ansi fg="command">mail</ansi> to archive, delete or reply to messages.</ansi>`)
 	user.SendText(``)
 
 	return true, nil
@@ -0,0 +1,25 @@
+package usercommands
+
+import (
+	"fmt"
+
+	"github.com/volte6/gomud/leaderboards"
+	"github.com/volte6/gomud/rooms"
+	"github.com/volte6/gomud/templates"
+	"github.com/volte6/gomud/users"
+)
+
+func Ladder(rest string, user *users.UserRecord, room *rooms.Room) (bool, error) {
+
+	// Rebuilt along with the leaderboards, so offline fighters are included without reading them from disk
+	ladderTxt, _ := templates.Process("character/ladder", leaderboards.GetLadder())
+	user.SendText(ladderTxt)
+
+	if user.Character.PvP.GetMatchCount() > 0 {
+		user.SendText(fmt.Sprintf(`Your rating is <ansi fg="yellow">%d</ansi> with <ansi fg="green">%d</ansi> wins and <ansi fg="red">%d</ansi> losses.`, user.Character.PvP.GetRating(), user.Character.PvP.Wins, user.Character.PvP.Losses))
+	} else {
+		user.SendText(`You haven't fought a ranked duel yet. Visit an arena and <ansi fg="command">duel</ansi> someone!`)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,83 @@
+package usercommands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/volte6/gomud/leaderboards"
+	"github.com/volte6/gomud/rooms"
+	"github.com/volte6/gomud/templates"
+	"github.com/volte6/gomud/users"
+	"github.com/volte6/gomud/util"
+)
+
+func Leaderboard(rest string, user *users.UserRecord, room *rooms.Room) (bool, error) {
+
+	categoryNames := []string{}
+	for _, category := range leaderboards.Categories {
+		categoryNames = append(categoryNames, string(category))
+	}
+
+	rest = strings.ToLower(strings.TrimSpace(rest))
+
+	if rest == `` {
+		user.SendText(`Leaderboards are kept for: <ansi fg="command">` + strings.Join(categoryNames, `</ansi>, <ansi fg="command">`) + `</ansi>`)
+		user.SendText(`Type <ansi fg="command">leaderboard [category]</ansi> to see who's on top.`)
+		return true, nil
+	}
+
+	// Built in the background when the server starts
+	if leaderboards.LastUpdated().IsZero() {
+		user.SendText(`The leaderboards are still being tallied. Try again in a moment.`)
+		return true, nil
+	}
+
+	match, closeMatch := util.FindMatchIn(rest, categoryNames...)
+	if match == `` {
+		match = closeMatch
+	}
+
+	board, ok := leaderboards.Get(leaderboards.Category(match))
+	if !ok {
+		user.SendText(fmt.Sprintf(`There is no "%s" leaderboard. Try one of: %s`, rest, strings.Join(categoryNames, `, `)))
+		return true, nil
+	}
+
+	if len(board.Entries) == 0 {
+		user.SendText(fmt.Sprintf(`Nobody has made the <ansi fg="yellow">%s</ansi> leaderboard yet.`, board.Title))
+		return true, nil
+	}
+
+	headers := []string{`#`, `Character`, board.Title}
+	rows := [][]string{}
+	formatting := [][]string{}
+
+	for i, entry := range board.Entries {
+
+		nameColor := `username`
+		if entry.CharacterName == user.Character.Name {
+			nameColor = `yellow-bold`
+		}
+
+		rows = append(rows, []string{
+			fmt.Sprintf(`%d.`, i+1),
+			entry.CharacterName,
+			entry.Details,
+		})
+
+		formatting = append(formatting, []string{
+			`<ansi fg="7">%s</ansi>`,
+			`<ansi fg="` + nameColor + `">%s</ansi>`,
+			`<ansi fg="white-bold">%s</ansi>`,
+		})
+	}
+
+	boardTableData := templates.GetTable(fmt.Sprintf(`Leaderboard: %s`, board.Title), headers, rows, formatting...)
+	boardTxt, _ := templates.Process("tables/generic", boardTableData)
+	user.SendText(boardTxt)
+
+	user.SendText(fmt.Sprintf(`<ansi fg="7">Last updated %d minutes ago.</ansi>`, int(time.Since(leaderboards.LastUpdated()).Minutes())))
+
+	return true, nil
+}
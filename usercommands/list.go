@@ -35,6 +35,13 @@ func List(rest string, user *users.UserRecord, room *rooms.Room) (bool, error) {
 
 		listedSomething = true
 
+		// Prices depend on the merchant's faction
+		standing, _ := user.Character.GetFactionStanding(mob.Groups)
+		if !standing.WillTrade() {
+			mob.Command(`say I don't deal with the likes of you.`)
+			continue
+		}
+
 		itemsAvailable := characters.Shop{}
 		mercsAvailable := characters.Shop{}
 		buffsAvailable := characters.Shop{}
@@ -89,7 +96,7 @@ func List(rest string, user *users.UserRecord, room *rooms.Room) (bool, error) {
 					qtyStr,
 					fmt.Sprintf(`<ansi fg="itemname">%s</ansi>`, item.DisplayName()) + strings.Repeat(" ", 30-len(item.Name())),
 					string(item.GetSpec().Type),
-					strconv.Itoa(price)},
+					strconv.Itoa(standing.BuyPrice(price))},
 				)
 			}
 
@@ -137,7 +144,7 @@ func List(rest string, user *users.UserRecord, room *rooms.Room) (bool, error) {
 					`<ansi fg="mobname">` + mobInfo.Character.Name + `</ansi>` + strings.Repeat(" ", 30-len(mobInfo.Character.Name)),
 					strconv.Itoa(mobInfo.Character.Level),
 					raceInfo.Name,
-					strconv.Itoa(price),
+					strconv.Itoa(standing.BuyPrice(price)),
 				})
 
 			}
@@ -174,7 +181,7 @@ func List(rest string, user *users.UserRecord, room *rooms.Room) (bool, error) {
 				rows = append(rows, []string{
 					qtyStr,
 					buffInfo.Name + strings.Repeat(" ", 30-len(buffInfo.Name)),
-					strconv.Itoa(stockBuff.Price)},
+					strconv.Itoa(standing.BuyPrice(stockBuff.Price))},
 				)
 			}
 
@@ -215,7 +222,7 @@ func List(rest string, user *users.UserRecord, room *rooms.Room) (bool, error) {
 				rows = append(rows, []string{
 					qtyStr,
 					`<ansi fg="petname">` + petInfo.Type + strings.Repeat(" ", 30-len(petInfo.Type)) + `</ansi>`,
-					strconv.Itoa(price)},
+					strconv.Itoa(standing.BuyPrice(price))},
 				)
 			}
 
@@ -0,0 +1,539 @@
+package usercommands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/volte6/gomud/chatlog"
+	"github.com/volte6/gomud/configs"
+	"github.com/volte6/gomud/rooms"
+	"github.com/volte6/gomud/templates"
+	"github.com/volte6/gomud/term"
+	"github.com/volte6/gomud/users"
+	"github.com/volte6/gomud/util"
+)
+
+const (
+	MailRecipientKey = `mail-recipient` // username of whoever a letter being written is addressed to
+
+	mailMessageMaxLength = 1024
+	postOfficeName       = `Post Office`
+)
+
+// Sends and manages letters between players.
+// Letters can be read anywhere, but only sent from a post office.
+func Mail(rest string, user *users.UserRecord, room *rooms.Room) (bool, error) {
+
+	args := util.SplitButRespectQuotes(rest)
+
+	if len(args) == 0 {
+		mailList(user, false)
+		return true, nil
+	}
+
+	cmd := strings.ToLower(args[0])
+	args = args[1:]
+
+	if cmd == `send` || cmd == `write` || cmd == `compose` {
+		if len(args) == 0 {
+			user.SendText(`Send a letter to who? Type <ansi fg="command">mail send [player]</ansi>.`)
+			return true, nil
+		}
+		return mailCompose(rest, args[0], user, room)
+	}
+
+	if cmd == `archived` {
+		mailList(user, true)
+		return true, nil
+	}
+
+	if cmd != `read` && cmd != `reply` && cmd != `pay` && cmd != `archive` && cmd != `delete` {
+		user.SendText(`Try <ansi fg="command">help mail</ansi> to see what you can do with your mail.`)
+		return true, nil
+	}
+
+	if len(args) == 0 {
+		user.SendText(fmt.Sprintf(`Which letter? Type <ansi fg="command">mail %s [#]</ansi>.`, cmd))
+		return true, nil
+	}
+
+	idx, _ := strconv.Atoi(strings.TrimPrefix(args[0], `#`))
+	idx--
+
+	msg, ok := user.Inbox.Get(idx)
+	if !ok {
+		user.SendText(fmt.Sprintf(`You don't have a letter #%s. Type <ansi fg="command">mail</ansi> to see your letters.`, args[0]))
+		return true, nil
+	}
+
+	switch cmd {
+
+	case `read`:
+
+		tplTxt, _ := templates.Process("mail/message", msg)
+		user.SendText(tplTxt)
+
+		if !msg.Read && !msg.IsCODPending() {
+			collectAttachments(user, msg)
+		}
+		user.Inbox[idx].Read = true
+
+	case `reply`:
+
+		if msg.FromUserId == 0 {
+			user.SendText(`You can't reply to that letter.`)
+			return true, nil
+		}
+		return mailCompose(rest, msg.FromName, user, room)
+
+	case `pay`:
+
+		if !msg.IsCODPending() {
+			user.SendText(`Nothing is owed on that letter.`)
+			return true, nil
+		}
+
+		if user.Character.Gold < msg.COD {
+			user.SendText(fmt.Sprintf(`You need <ansi fg="gold">%d gold</ansi> on hand to pay for that letter.`, msg.COD))
+			return true, nil
+		}
+
+		user.Character.Gold -= msg.COD
+
+		deliverMail(msg.FromUserId, users.Message{
+			FromName: postOfficeName,
+			Message:  fmt.Sprintf(`%s paid %d gold on delivery of your letter.`, user.Character.Name, msg.COD),
+			Gold:     msg.COD,
+		})
+
+		user.SendText(fmt.Sprintf(`You pay <ansi fg="gold">%d gold</ansi> to <ansi fg="username">%s</ansi>.`, msg.COD, msg.FromName))
+
+		collectAttachments(user, msg)
+
+		user.Inbox[idx].COD = 0
+		user.Inbox[idx].Read = true
+
+	case `archive`:
+
+		if msg.Archived {
+			if user.Inbox.IsFull() {
+				user.SendText(`Your inbox is full. Delete some letters first.`)
+				return true, nil
+			}
+			user.Inbox[idx].Archived = false
+			user.SendText(fmt.Sprintf(`Letter #%d has been moved back to your inbox.`, idx+1))
+			return true, nil
+		}
+
+		if !msg.Read || msg.IsCODPending() {
+			user.SendText(`You can only archive letters you have read and paid for.`)
+			return true, nil
+		}
+
+		if user.Inbox.CountArchived() >= int(configs.GetConfig().MailArchiveSize) {
+			user.SendText(`Your archive is full. Delete some archived letters first.`)
+			return true, nil
+		}
+
+		user.Inbox[idx].Archived = true
+		user.SendText(fmt.Sprintf(`Letter #%d has been archived.`, idx+1))
+
+	case `delete`:
+
+		if msg.IsCODPending() {
+			returnToSender(msg)
+			user.SendText(`You refuse the letter, and it is returned to the sender.`)
+		} else {
+			// Attachments are collected when a letter is read, so don't throw away any that haven't been
+			if !msg.Read && (msg.Item != nil || msg.Gold > 0) {
+				collectAttachments(user, msg)
+				user.SendText(`You take what was attached to the letter before throwing it away.`)
+			}
+			user.SendText(fmt.Sprintf(`Letter #%d has been deleted.`, idx+1))
+		}
+
+		user.Inbox.Remove(idx)
+	}
+
+	return true, nil
+}
+
+func mailList(user *users.UserRecord, archived bool) {
+
+	c := configs.GetConfig()
+
+	title := fmt.Sprintf(`Inbox (%d/%d)`, user.Inbox.CountActive(), c.MailInboxSize)
+	if archived {
+		title = fmt.Sprintf(`Archived Letters (%d/%d)`, user.Inbox.CountArchived(), c.MailArchiveSize)
+	}
+
+	headers := []string{`#`, `From`, `Sent`, `Attached`}
+	rows := [][]string{}
+	formatting := [][]string{}
+
+	for idx, msg := range user.Inbox {
+
+		if msg.Archived != archived {
+			continue
+		}
+
+		attached := []string{}
+		if msg.Item != nil {
+			attached = append(attached, msg.Item.NameSimple())
+		}
+		if msg.Gold > 0 {
+			attached = append(attached, fmt.Sprintf(`%d gold`, msg.Gold))
+		}
+		if msg.IsCODPending() {
+			attached = append(attached, fmt.Sprintf(`%d gold due`, msg.COD))
+		}
+
+		numberColor := `7`
+		if !msg.Read {
+			numberColor = `alert-5`
+		}
+
+		rows = append(rows, []string{
+			fmt.Sprintf(`%d.`, idx+1),
+			msg.FromName,
+			msg.DateString(),
+			strings.Join(attached, `, `),
+		})
+
+		formatting = append(formatting, []string{
+			`<ansi fg="` + numberColor + `">%s</ansi>`,
+			`<ansi fg="username">%s</ansi>`,
+			`<ansi fg="mail-date">%s</ansi>`,
+			`<ansi fg="itemname">%s</ansi>`,
+		})
+	}
+
+	if len(rows) == 0 {
+		if archived {
+			user.SendText(`You have no archived letters.`)
+		} else {
+			user.SendText(`You have no letters.`)
+		}
+	} else {
+		mailTableData := templates.GetTable(title, headers, rows, formatting...)
+		mailTxt, _ := templates.Process("tables/generic", mailTableData)
+		user.SendText(mailTxt)
+	}
+
+	user.SendText(`Type <ansi fg="command">mail read [#]</ansi> to read a letter, or <ansi fg="command">help mail</ansi> for more.`)
+}
+
+// Walks a player through writing a letter, then posts it.
+func mailCompose(rest string, toName string, user *users.UserRecord, room *rooms.Room) (bool, error) {
+
+	c := configs.GetConfig()
+
+	if !room.IsPostOffice {
+		user.ClearPrompt()
+		user.SendText(`You can only send letters from a post office.`)
+		return true, nil
+	}
+
+	if user.MailRemaining() < 1 {
+		user.ClearPrompt()
+		user.SendText(fmt.Sprintf(`You can only send %d letters an hour. Try again later.`, c.MailSendLimit))
+		return true, nil
+	}
+
+	cmdPrompt, isNew := user.StartPrompt(`mail`, rest)
+
+	if isNew {
+
+		recipient := findPlayer(toName)
+		if recipient == nil {
+			user.ClearPrompt()
+			user.SendText(fmt.Sprintf(`The clerk can't find anyone called "%s" to deliver to.`, toName))
+			return true, nil
+		}
+
+		if recipient.UserId == user.UserId {
+			user.ClearPrompt()
+			user.SendText(`You can't send a letter to yourself.`)
+			return true, nil
+		}
+
+		if err := canMail(user, recipient); err != `` {
+			user.ClearPrompt()
+			user.SendText(err)
+			return true, nil
+		}
+
+		user.SetTempData(MailRecipientKey, recipient.Username)
+
+		user.SendText(fmt.Sprintf(`You start a letter to <ansi fg="username">%s</ansi>.%s`, recipient.Character.Name, term.CRLFStr))
+	}
+
+	recipientUsername, _ := user.GetTempData(MailRecipientKey).(string)
+	if recipientUsername == `` {
+		user.ClearPrompt()
+		return true, nil
+	}
+
+	msg := users.Message{
+		FromUserId: user.UserId,
+		FromName:   user.Character.Name,
+	}
+
+	//
+	// Message?
+	//
+	question := cmdPrompt.AskMultiLine(`Message?`, ``)
+	if !question.Done {
+		return true, nil
+	}
+
+	if question.Response == `` {
+		user.ClearPrompt()
+		user.SendText(`You decide not to send a letter after all.`)
+		return true, nil
+	}
+
+	if len(question.Response) > mailMessageMaxLength {
+		user.SendText(fmt.Sprintf(`Letters can't be longer than %d characters.`, mailMessageMaxLength))
+		question.RejectResponse()
+		return true, nil
+	}
+
+	msg.Message = question.Response
+
+	//
+	// Gold?
+	//
+	question = cmdPrompt.Ask(`Attach how much gold?`, []string{}, `0`)
+	if !question.Done {
+		return true, nil
+	}
+
+	msg.Gold, _ = strconv.Atoi(question.Response)
+	if msg.Gold < 0 || msg.Gold+int(c.MailPostage) > user.Character.Gold {
+		user.SendText(fmt.Sprintf(`You only have <ansi fg="gold">%d gold</ansi>, and postage is <ansi fg="gold">%d gold</ansi>.`, user.Character.Gold, c.MailPostage))
+		question.RejectResponse()
+		return true, nil
+	}
+
+	//
+	// Attach item?
+	//
+	question = cmdPrompt.Ask(`Item name (or "none") to attach from your backpack?`, []string{}, `none`)
+	if !question.Done {
+		return true, nil
+	}
+
+	if question.Response != `none` {
+		itemAttached, found := user.Character.FindInBackpack(question.Response)
+		if !found {
+			user.SendText(`Could not find item: ` + question.Response)
+			question.RejectResponse()
+			return true, nil
+		}
+		msg.Item = &itemAttached
+	}
+
+	//
+	// Cash on delivery?
+	//
+	if msg.Item != nil {
+
+		question = cmdPrompt.Ask(`Gold to collect from them on delivery (0 for none)?`, []string{}, `0`)
+		if !question.Done {
+			return true, nil
+		}
+
+		msg.COD, _ = strconv.Atoi(question.Response)
+		if msg.COD < 0 {
+			msg.COD = 0
+		}
+	}
+
+	//
+	// Display preview?
+	//
+	question = cmdPrompt.Ask(fmt.Sprintf(`Send this letter for %d gold postage?`, c.MailPostage), []string{`Yes`, `No`}, `No`)
+	if !question.Done {
+
+		tplTxt, _ := templates.Process("mail/message", msg)
+		user.SendText(tplTxt)
+
+		return true, nil
+	}
+
+	user.ClearPrompt()
+	user.SetTempData(MailRecipientKey, nil)
+
+	if question.Response[0:1] != `Y` {
+		user.SendText(`You tear up the letter.`)
+		return true, nil
+	}
+
+	// Things might have changed while they were writing
+	if user.Character.Gold < msg.Gold+int(c.MailPostage) {
+		user.SendText(`You no longer have enough gold to send that.`)
+		return true, nil
+	}
+
+	if msg.Item != nil && !user.Character.RemoveItem(*msg.Item) {
+		user.SendText(`You no longer have the item you meant to attach.`)
+		return true, nil
+	}
+
+	user.Character.Gold -= msg.Gold + int(c.MailPostage)
+
+	recipient := findPlayerByUsername(recipientUsername)
+	if recipient == nil || canMail(user, recipient) != `` {
+		// Give it all back
+		user.Character.Gold += msg.Gold + int(c.MailPostage)
+		if msg.Item != nil {
+			user.Character.StoreItem(*msg.Item)
+		}
+		user.SendText(`The clerk can't deliver your letter, and hands it back.`)
+		return true, nil
+	}
+
+	postMail(recipient, msg)
+	user.TrackMailSent()
+
+	chatlog.Log(chatlog.Entry{
+		Kind:       chatlog.KindMail,
+		RoomId:     room.RoomId,
+		FromUserId: user.UserId,
+		FromName:   user.Character.Name,
+		ToUserId:   recipient.UserId,
+		ToName:     recipient.Character.Name,
+		Text:       msg.Message,
+	})
+
+	user.SendText(fmt.Sprintf(`You hand your letter to the clerk and pay <ansi fg="gold">%d gold</ansi> postage. It's on its way to <ansi fg="username">%s</ansi>.`, c.MailPostage, recipient.Character.Name))
+	room.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> posts a letter.`, user.Character.Name), user.UserId)
+
+	return true, nil
+}
+
+// Returns an empty string if user can send a letter to recipient, otherwise the reason they can't.
+func canMail(user *users.UserRecord, recipient *users.UserRecord) string {
+
+	sourceIsMod := user.Permission == users.PermissionAdmin || user.Permission == users.PermissionMod
+	targetIsMod := recipient.Permission == users.PermissionAdmin || recipient.Permission == users.PermissionMod
+
+	if user.Muted && !targetIsMod {
+		return `You are <ansi fg="alert-5">MUTED</ansi>. You can only send letters to Admins and Moderators.`
+	}
+
+	if recipient.Deafened && !sourceIsMod {
+		return `That user is <ansi fg="alert-5">DEAFENED</ansi> and cannot receive letters from other players.`
+	}
+
+	// Refused rather than dropped, so nothing attached is lost
+	if recipient.IsIgnoring(user.UserId) && !sourceIsMod {
+		return fmt.Sprintf(`%s isn't accepting letters from you.`, recipient.Character.Name)
+	}
+
+	if recipient.Inbox.IsFull() {
+		return fmt.Sprintf(`%s's inbox is full.`, recipient.Character.Name)
+	}
+
+	return ``
+}
+
+// Finds who owns a character, whether they are online or not.
+func findPlayer(characterName string) *users.UserRecord {
+
+	if u := users.GetByCharacterName(characterName); u != nil {
+		return u
+	}
+
+	// Might be offline, or playing an alt
+	_, username := users.CharacterNameSearch(characterName)
+	if username == `` {
+		return nil
+	}
+
+	return findPlayerByUsername(username)
+}
+
+func findPlayerByUsername(username string) *users.UserRecord {
+
+	for _, u := range users.GetAllActiveUsers() {
+		if u.Username == username {
+			return u
+		}
+	}
+
+	u, err := users.LoadUser(username)
+	if err != nil {
+		return nil
+	}
+
+	return u
+}
+
+func findPlayerByUserId(userId int) *users.UserRecord {
+
+	if u := users.GetByUserId(userId); u != nil {
+		return u
+	}
+
+	var found *users.UserRecord
+	users.SearchOfflineUsers(func(u *users.UserRecord) bool {
+		if u.UserId == userId {
+			found = u
+			return false
+		}
+		return true
+	})
+
+	return found
+}
+
+// Puts a letter in a users inbox, whether they are online or not.
+// Letters from the post office itself are delivered even if the inbox is full.
+func deliverMail(userId int, msg users.Message) bool {
+
+	u := findPlayerByUserId(userId)
+	if u == nil {
+		return false
+	}
+
+	postMail(u, msg)
+
+	return true
+}
+
+func postMail(u *users.UserRecord, msg users.Message) {
+
+	u.Inbox.Add(msg)
+
+	if users.GetByUserId(u.UserId) != nil {
+		u.Command(`inbox check`)
+		return
+	}
+
+	users.SaveUser(*u)
+}
+
+// Sends a refused letter's attachments back to whoever sent it
+func returnToSender(msg users.Message) {
+
+	returned := users.Message{
+		FromName: postOfficeName,
+		Message:  fmt.Sprintf(`Your letter was refused and has been returned. It read: %s`, msg.Message),
+		Item:     msg.Item,
+		Gold:     msg.Gold,
+	}
+
+	deliverMail(msg.FromUserId, returned)
+}
+
+func collectAttachments(user *users.UserRecord, msg users.Message) {
+	if msg.Gold > 0 {
+		user.Character.Bank += msg.Gold
+	}
+	if msg.Item != nil {
+		user.Character.StoreItem(*msg.Item)
+	}
+}
@@ -0,0 +1,62 @@
+package usercommands
+
+import (
+	"fmt"
+
+	"github.com/volte6/gomud/rooms"
+	"github.com/volte6/gomud/users"
+)
+
+func Mount(rest string, user *users.UserRecord, room *rooms.Room) (bool, error) {
+
+	if !user.Character.Pet.Exists() {
+		user.SendText(`You don't have anything to ride.`)
+		return true, nil
+	}
+
+	if !user.Character.Pet.Mountable {
+		user.SendText(fmt.Sprintf(`%s isn't something you can ride.`, user.Character.Pet.DisplayName()))
+		return true, nil
+	}
+
+	if user.Character.Pet.Mounted {
+		user.SendText(fmt.Sprintf(`You are already riding %s.`, user.Character.Pet.DisplayName()))
+		return true, nil
+	}
+
+	if user.Character.Aggro != nil {
+		user.SendText(`You can't do that! You are in combat!`)
+		return true, nil
+	}
+
+	if room.IsIndoors {
+		user.SendText(fmt.Sprintf(`There isn't enough room to ride %s in here.`, user.Character.Pet.DisplayName()))
+		return true, nil
+	}
+
+	user.Character.Pet.Mounted = true
+
+	user.SendText(fmt.Sprintf(`You climb up onto %s.`, user.Character.Pet.DisplayName()))
+	room.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> climbs up onto %s.`, user.Character.Name, user.Character.Pet.DisplayName()), user.UserId)
+
+	return true, nil
+}
+
+func Dismount(rest string, user *users.UserRecord, room *rooms.Room) (bool, error) {
+
+	if !user.Character.Pet.IsRidden() {
+		user.SendText(`You aren't riding anything.`)
+		return true, nil
+	}
+
+	user.Character.Pet.Mounted = false
+
+	user.SendText(fmt.Sprintf(`You climb down from %s.`, user.Character.Pet.DisplayName()))
+	room.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> climbs down from %s.`, user.Character.Name, user.Character.Pet.DisplayName()), user.UserId)
+
+	if len(user.Character.Items) > user.Character.CarryCapacity() {
+		user.SendText(`You feel the full weight of everything you're carrying. You are <ansi fg="red">encumbered</ansi>.`)
+	}
+
+	return true, nil
+}
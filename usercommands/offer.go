@@ -38,7 +38,13 @@ func Offer(rest string, user *users.UserRecord, room *rooms.Room) (bool, error)
 			continue
 		}
 
-		sellValue := mob.GetSellPrice(item)
+		standing, _ := user.Character.GetFactionStanding(mob.Groups)
+		if !standing.WillTrade() {
+			mob.Command(`say I don't deal with the likes of you.`)
+			continue
+		}
+
+		sellValue := standing.SellPrice(mob.GetSellPrice(item))
 
 		if sellValue <= 0 {
 
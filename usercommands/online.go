@@ -37,8 +37,13 @@ func Online(rest string, user *users.UserRecord, room *rooms.Room) (bool, error)
 				onlineTime += ` <ansi fg="8">(afk)</ansi>`
 			}
 
+			characterName := onlineInfo.CharacterName
+			if onlineInfo.Title != `` {
+				characterName += ` <ansi fg="title">` + onlineInfo.Title + `</ansi>`
+			}
+
 			row := []string{
-				onlineInfo.CharacterName,
+				characterName,
 				strconv.Itoa(onlineInfo.Level),
 				onlineInfo.Alignment,
 				onlineInfo.Profession,
@@ -3,16 +3,24 @@ package usercommands
 import (
 	"fmt"
 	"math"
+	"sort"
+	"strconv"
 	"strings"
 
+	"github.com/volte6/gomud/events"
 	"github.com/volte6/gomud/mobs"
 	"github.com/volte6/gomud/parties"
+	"github.com/volte6/gomud/quests"
 	"github.com/volte6/gomud/rooms"
 	"github.com/volte6/gomud/templates"
 	"github.com/volte6/gomud/users"
 	"github.com/volte6/gomud/util"
 )
 
+const (
+	PartyQuestOfferKey = `party-quest-offer` // questId the party leader offered to share with this user
+)
+
 func Party(rest string, user *users.UserRecord, room *rooms.Room) (bool, error) {
 
 	args := util.SplitButRespectQuotes(rest)
@@ -85,6 +93,12 @@ func Party(rest string, user *users.UserRecord, room *rooms.Room) (bool, error)
 
 		invitedUser := users.GetByUserId(invitePlayerId)
 
+		// Invites from ignored players are quietly dropped
+		if invitedUser != nil && invitedUser.IsIgnoring(user.UserId) {
+			user.SendText(fmt.Sprintf(`You invited <ansi fg="username">%s</ansi> to your party.`, invitedUser.Character.Name))
+			return true, nil
+		}
+
 		if invitedUser != nil && currentParty.InvitePlayer(invitePlayerId) {
 			user.SendText(fmt.Sprintf(`You invited <ansi fg="username">%s</ansi> to your party.`, invitedUser.Character.Name))
 			invitedUser.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> invited you to their party. Type <ansi fg="command">party accept</ansi> or <ansi fg="command">party decline</ansi> to respond.`, user.Character.Name))
@@ -470,6 +484,10 @@ func Party(rest string, user *users.UserRecord, room *rooms.Room) (bool, error)
 
 	}
 
+	if partyCommand == `quest` {
+		return partyQuest(rest, user, currentParty)
+	}
+
 	if partyCommand == `chat` || partyCommand == `say` {
 
 		if len(rest) == 0 {
@@ -491,3 +509,172 @@ func Party(rest string, user *users.UserRecord, room *rooms.Room) (bool, error)
 
 	return true, nil
 }
+
+// Shares quests between party members.
+// The leader offers one of their active quests, and members who meet its requirements are asked whether to accept.
+func partyQuest(rest string, user *users.UserRecord, currentParty *parties.Party) (bool, error) {
+
+	args := util.SplitButRespectQuotes(strings.ToLower(rest))
+
+	// A member answering an offer from the leader
+	if len(args) > 1 && args[0] == `accept` {
+
+		questId, _ := strconv.Atoi(args[1])
+		questToken := quests.PartsToToken(questId, `start`)
+
+		offeredQuestId, _ := user.GetTempData(PartyQuestOfferKey).(int)
+		leader := users.GetByUserId(currentParty.LeaderUserId)
+		questInfo := quests.GetQuest(questToken)
+
+		if offeredQuestId != questId || leader == nil || questInfo == nil || !leader.Character.HasQuest(questToken) {
+			user.SetTempData(PartyQuestOfferKey, nil)
+			user.ClearPrompt()
+			user.SendText(`That quest is no longer being shared with you.`)
+			return true, nil
+		}
+
+		cmdPrompt, _ := user.StartPrompt(`party`, `quest `+rest)
+		question := cmdPrompt.Ask(fmt.Sprintf(`Accept the quest %s from %s?`, questInfo.Name, leader.Character.Name), []string{`Yes`, `No`}, `No`)
+		if !question.Done {
+			return true, nil
+		}
+
+		user.ClearPrompt()
+		user.SetTempData(PartyQuestOfferKey, nil)
+
+		if question.Response != `Yes` {
+			user.SendText(fmt.Sprintf(`You decline the quest <ansi fg="questname">%s</ansi>.`, questInfo.Name))
+			leader.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> declines the quest <ansi fg="questname">%s</ansi>.`, user.Character.Name, questInfo.Name))
+			return true, nil
+		}
+
+		if user.Character.HasQuest(questToken) || !user.Character.MeetsQuestRequirements(questId) {
+			user.SendText(fmt.Sprintf(`You can't take on <ansi fg="questname">%s</ansi>.`, questInfo.Name))
+			return true, nil
+		}
+
+		events.AddToQueue(events.Quest{
+			UserId:     user.UserId,
+			QuestToken: questToken,
+		})
+
+		leader.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> accepts the quest <ansi fg="questname">%s</ansi>.`, user.Character.Name, questInfo.Name))
+
+		return true, nil
+	}
+
+	if len(args) == 0 || args[0] != `share` {
+		user.SendText(`Usage: <ansi fg="command">party quest share [quest]</ansi>`)
+		return true, nil
+	}
+
+	if !currentParty.IsLeader(user.UserId) {
+		user.SendText(`You are not the leader of your party.`)
+		return true, nil
+	}
+
+	// Only quests the leader is part way through can be shared
+	activeQuestIds := []int{}
+	activeQuestNames := []string{}
+	for questId, stepId := range user.Character.QuestProgress {
+		if stepId == `end` {
+			continue
+		}
+		if questInfo := quests.GetQuest(quests.PartsToToken(questId, stepId)); questInfo != nil && !questInfo.Secret {
+			activeQuestIds = append(activeQuestIds, questId)
+		}
+	}
+	sort.Ints(activeQuestIds)
+
+	for _, questId := range activeQuestIds {
+		activeQuestNames = append(activeQuestNames, quests.GetQuest(quests.PartsToToken(questId, `start`)).Name)
+	}
+
+	if len(activeQuestIds) == 0 {
+		user.SendText(`You don't have any quests to share.`)
+		return true, nil
+	}
+
+	if len(args) < 2 {
+		user.SendText(`Which quest do you want to share?`)
+		for i, questId := range activeQuestIds {
+			user.SendText(fmt.Sprintf(`  <ansi fg="white-bold">#%d</ansi> <ansi fg="questname">%s</ansi>`, questId, activeQuestNames[i]))
+		}
+		user.SendText(`Type <ansi fg="command">party quest share [#]</ansi> to share one with your party.`)
+		return true, nil
+	}
+
+	shareQuestId := 0
+	questSearch := strings.TrimPrefix(strings.Join(args[1:], ` `), `#`)
+
+	if num, err := strconv.Atoi(questSearch); err == nil {
+		for _, questId := range activeQuestIds {
+			if questId == num {
+				shareQuestId = questId
+			}
+		}
+	} else {
+		matchName, closeMatchName := util.FindMatchIn(questSearch, activeQuestNames...)
+		if matchName == `` {
+			matchName = closeMatchName
+		}
+		for i, questName := range activeQuestNames {
+			if matchName != `` && questName == matchName {
+				shareQuestId = activeQuestIds[i]
+			}
+		}
+	}
+
+	if shareQuestId == 0 {
+		user.SendText(`You aren't on that quest.`)
+		return true, nil
+	}
+
+	questToken := quests.PartsToToken(shareQuestId, `start`)
+	questInfo := quests.GetQuest(questToken)
+
+	offeredCt := 0
+	for _, uid := range currentParty.GetMembers() {
+
+		if uid == user.UserId {
+			continue
+		}
+
+		member := users.GetByUserId(uid)
+		if member == nil {
+			continue
+		}
+
+		if member.Character.HasQuest(questToken) {
+			user.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> is already on that quest.`, member.Character.Name))
+			continue
+		}
+
+		if !member.Character.MeetsQuestRequirements(shareQuestId) {
+			user.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> can't take on that quest yet.`, member.Character.Name))
+			continue
+		}
+
+		if member.GetPrompt() != nil {
+			user.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> is busy. Try again in a moment.`, member.Character.Name))
+			continue
+		}
+
+		member.SetTempData(PartyQuestOfferKey, shareQuestId)
+
+		// The prompt is answered by the member, and brings them back here to accept
+		cmdPrompt, _ := member.StartPrompt(`party`, fmt.Sprintf(`quest accept %d`, shareQuestId))
+		cmdPrompt.Ask(fmt.Sprintf(`Accept the quest %s from %s?`, questInfo.Name, user.Character.Name), []string{`Yes`, `No`}, `No`)
+
+		member.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> wants to share the quest <ansi fg="questname">%s</ansi> with you.`, user.Character.Name, questInfo.Name))
+		member.SendText(fmt.Sprintf(`  <ansi fg="yellow">%s</ansi>`, questInfo.Description))
+
+		offeredCt++
+	}
+
+	if offeredCt > 0 {
+		user.SendText(fmt.Sprintf(`You offer to share <ansi fg="questname">%s</ansi> with your party.`, questInfo.Name))
+	}
+
+	return true, nil
+}
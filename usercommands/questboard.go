@@ -0,0 +1,107 @@
+package usercommands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/volte6/gomud/events"
+	"github.com/volte6/gomud/quests"
+	"github.com/volte6/gomud/rooms"
+	"github.com/volte6/gomud/term"
+	"github.com/volte6/gomud/users"
+	"github.com/volte6/gomud/util"
+)
+
+func Questboard(rest string, user *users.UserRecord, room *rooms.Room) (bool, error) {
+
+	user.SendText(``)
+
+	if !room.IsQuestBoard() {
+		user.SendText(`There is no quest board here.` + term.CRLFStr)
+		return true, nil
+	}
+
+	offers := room.GetQuestBoardOffers()
+	args := util.SplitButRespectQuotes(strings.ToLower(rest))
+
+	if len(args) == 0 {
+
+		user.SendText(`<ansi fg="yellow-bold">Today's postings on the quest board:</ansi>`)
+
+		for i, questId := range offers {
+
+			questInfo := quests.GetQuest(quests.PartsToToken(questId, `start`))
+			if questInfo == nil {
+				continue
+			}
+
+			repeatText := ``
+			if questInfo.IsRepeatable() {
+				repeatText = fmt.Sprintf(` <ansi fg="240">(repeats every %s)</ansi>`, questInfo.Repeatable)
+			}
+
+			user.SendText(fmt.Sprintf(`  <ansi fg="white-bold">%d.</ansi> <ansi fg="questname">%s</ansi>%s - %s`, i+1, questInfo.Name, repeatText, getQuestBoardStatus(user, questId)))
+			user.SendText(fmt.Sprintf(`     %s`, questInfo.Description))
+		}
+
+		user.SendText(term.CRLFStr + `Type <ansi fg="command">questboard take [#]</ansi> to take on a quest. New quests are posted each day.` + term.CRLFStr)
+		return true, nil
+	}
+
+	if args[0] != `take` || len(args) < 2 {
+		user.SendText(`Type <ansi fg="command">questboard take [#]</ansi> to take on a quest.` + term.CRLFStr)
+		return true, nil
+	}
+
+	num, _ := strconv.Atoi(args[1])
+	if num < 1 || num > len(offers) {
+		user.SendText(`There is no posting with that number.` + term.CRLFStr)
+		return true, nil
+	}
+
+	questId := offers[num-1]
+	questToken := quests.PartsToToken(questId, `start`)
+
+	questInfo := quests.GetQuest(questToken)
+	if questInfo == nil {
+		user.SendText(`That posting has been torn down.` + term.CRLFStr)
+		return true, nil
+	}
+
+	if user.Character.HasQuest(questToken) || !user.Character.MeetsQuestRequirements(questId) {
+		user.SendText(fmt.Sprintf(`You can't take on <ansi fg="questname">%s</ansi> right now. (%s)%s`, questInfo.Name, getQuestBoardStatus(user, questId), term.CRLFStr))
+		return true, nil
+	}
+
+	events.AddToQueue(events.Quest{
+		UserId:     user.UserId,
+		QuestToken: questToken,
+	})
+
+	user.SendText(fmt.Sprintf(`You take a copy of the posting for <ansi fg="questname">%s</ansi>.`, questInfo.Name))
+	room.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> takes a posting from the quest board.`, user.Character.Name), user.UserId)
+
+	return true, nil
+}
+
+// Describes whether a user can take on a quest from the board
+func getQuestBoardStatus(user *users.UserRecord, questId int) string {
+
+	if !user.Character.HasQuest(quests.PartsToToken(questId, `start`)) {
+		if !user.Character.MeetsQuestRequirements(questId) {
+			return `<ansi fg="red">requirements not met</ansi>`
+		}
+		return `<ansi fg="green">available</ansi>`
+	}
+
+	if !user.Character.IsQuestDone(quests.PartsToToken(questId, `end`)) {
+		return `<ansi fg="yellow">in progress</ansi>`
+	}
+
+	if resetRound := user.Character.GetQuestResetRound(questId); resetRound > 0 {
+		return fmt.Sprintf(`<ansi fg="240">available again in %s</ansi>`, roundsToDurationText(resetRound-util.GetRoundCount()))
+	}
+
+	return `<ansi fg="240">completed</ansi>`
+}
@@ -4,7 +4,12 @@ import (
 	"fmt"
 	"math"
 	"sort"
+	"strconv"
+	"strings"
 
+	"github.com/volte6/gomud/configs"
+	"github.com/volte6/gomud/items"
+	"github.com/volte6/gomud/mobs"
 	"github.com/volte6/gomud/quests"
 	"github.com/volte6/gomud/rooms"
 	"github.com/volte6/gomud/templates"
@@ -21,6 +26,10 @@ func Quests(rest string, user *users.UserRecord, room *rooms.Room) (bool, error)
 		Completion  string
 		BarFull     string
 		BarEmpty    string
+		Objectives  []string
+		AvailableIn string
+		Path        string
+		Choice      string
 	}
 
 	type QuestInfo struct {
@@ -29,6 +38,10 @@ func Quests(rest string, user *users.UserRecord, room *rooms.Room) (bool, error)
 		Records     []QuestRecord
 	}
 
+	if strings.HasPrefix(rest, `choose`) {
+		return chooseQuestBranch(strings.TrimSpace(rest[len(`choose`):]), user)
+	}
+
 	showHidden := rest == `all+`
 	showComplete := (rest == `all`) || showHidden
 
@@ -78,7 +91,21 @@ func Quests(rest string, user *users.UserRecord, room *rooms.Room) (bool, error)
 
 			completion = float64(completedSteps) / float64(totalSteps)
 
-			if !showComplete && completion >= 1 {
+			// Repeatable quests are shown until they can be done again
+			availableIn := ``
+			if questStep == `end` {
+				if resetRound := user.Character.GetQuestResetRound(questId); resetRound > 0 {
+					availableIn = roundsToDurationText(resetRound - util.GetRoundCount())
+				}
+			}
+
+			// A choice may still be waiting on them, even at the end of a quest
+			choice := ``
+			if step, ok := user.Character.GetPendingQuestBranches(questId); ok && step.BranchPrompt != `` {
+				choice = fmt.Sprintf(`quests choose %d`, questId)
+			}
+
+			if !showComplete && completion >= 1 && availableIn == `` && choice == `` {
 				continue
 			}
 
@@ -91,6 +118,22 @@ func Quests(rest string, user *users.UserRecord, room *rooms.Room) (bool, error)
 				Completion:  fmt.Sprintf(`%d%%`, int(math.Floor(completion*100))),
 				BarFull:     barFull,
 				BarEmpty:    barEmpty,
+				Objectives:  []string{},
+				AvailableIn: availableIn,
+				Choice:      choice,
+			}
+
+			// Show the path taken
+			if branch, ok := user.Character.GetQuestBranch(questId); ok {
+				qDisplay.Path = branch.Description
+			}
+
+			// Show counters for any objectives of the current step
+			if step := questInfo.GetStep(questStep); step != nil {
+				progress := user.Character.GetQuestObjectiveProgress(questId, user.GetPartyItems()...)
+				for i, objective := range step.Objectives {
+					qDisplay.Objectives = append(qDisplay.Objectives, fmt.Sprintf(`%s: %d/%d`, getObjectiveLabel(objective), progress[i], objective.Quantity))
+				}
 			}
 
 			allQuests = append(allQuests, qDisplay)
@@ -110,3 +153,126 @@ func Quests(rest string, user *users.UserRecord, room *rooms.Room) (bool, error)
 
 	return true, nil
 }
+
+// Asks the player which branch of a quest they will take, if the quest is waiting on them to choose.
+func chooseQuestBranch(rest string, user *users.UserRecord) (bool, error) {
+
+	questId, _ := strconv.Atoi(rest)
+
+	step, ok := user.Character.GetPendingQuestBranches(questId)
+	if !ok || step.BranchPrompt == `` {
+		user.SendText(`You have no choice to make for that quest.`)
+		return true, nil
+	}
+
+	questInfo := quests.GetQuest(quests.PartsToToken(questId, step.Id))
+	if questInfo == nil {
+		return true, nil
+	}
+
+	cmdPrompt, isNew := user.StartPrompt(`quests`, `choose `+rest)
+
+	options := []string{}
+	for _, branch := range step.Branches {
+		options = append(options, branch.Id)
+	}
+	options = append(options, `later`)
+
+	if isNew {
+		user.SendText(fmt.Sprintf(`The quest <ansi fg="questname">%s</ansi> has reached a turning point:`, questInfo.Name))
+		for _, branch := range step.Branches {
+			user.SendText(fmt.Sprintf(`  <ansi fg="command">%s</ansi> - %s`, branch.Id, branch.Description))
+		}
+	}
+
+	question := cmdPrompt.Ask(step.BranchPrompt, options, `later`)
+	if !question.Done {
+		return true, nil
+	}
+
+	user.ClearPrompt()
+
+	if question.Response == `later` {
+		user.SendText(fmt.Sprintf(`You put off the decision. Type <ansi fg="command">quests choose %d</ansi> when you are ready.`, questId))
+		return true, nil
+	}
+
+	branch := step.GetBranch(question.Response)
+	if branch == nil || !user.ChooseQuestBranch(questId, branch.Id) {
+		user.SendText(`That choice is no longer available.`)
+		return true, nil
+	}
+
+	user.SendText(fmt.Sprintf(`You have chosen: <ansi fg="yellow">%s</ansi>`, branch.Description))
+
+	return true, nil
+}
+
+// Describes an objective using the names of whatever it involves, unless it has its own description.
+func getObjectiveLabel(objective quests.QuestObjective) string {
+
+	if objective.Description != `` {
+		return objective.Description
+	}
+
+	mobName := `someone`
+	if mobSpec := mobs.GetMobSpec(mobs.MobId(objective.MobId)); mobSpec != nil {
+		mobName = mobSpec.Character.Name
+	}
+
+	itemName := `something`
+	if itemSpec := items.GetItemSpec(objective.ItemId); itemSpec != nil {
+		itemName = itemSpec.Name
+	}
+
+	switch objective.Type {
+	case quests.ObjectiveKill:
+		return fmt.Sprintf(`Kill %s`, mobName)
+	case quests.ObjectiveCollect:
+		return fmt.Sprintf(`Collect %s`, itemName)
+	case quests.ObjectiveVisit:
+		if room := rooms.LoadRoom(objective.RoomId); room != nil {
+			return fmt.Sprintf(`Visit %s`, room.Title)
+		}
+		return `Visit somewhere`
+	case quests.ObjectiveTalk:
+		return fmt.Sprintf(`Talk to %s`, mobName)
+	case quests.ObjectiveDeliver:
+		return fmt.Sprintf(`Deliver %s to %s`, itemName, mobName)
+	}
+
+	return string(objective.Type)
+}
+
+// Describes how long a number of rounds will take in real time, such as "2 hours, 5 minutes"
+func roundsToDurationText(rounds uint64) string {
+
+	seconds := configs.GetConfig().RoundsToSeconds(int(rounds))
+
+	days := seconds / 86400
+	hours := (seconds % 86400) / 3600
+	minutes := (seconds % 3600) / 60
+
+	parts := []string{}
+	for _, unit := range []struct {
+		qty  int
+		name string
+	}{{days, `day`}, {hours, `hour`}, {minutes, `minute`}} {
+		if unit.qty == 1 {
+			parts = append(parts, fmt.Sprintf(`1 %s`, unit.name))
+		} else if unit.qty > 1 {
+			parts = append(parts, fmt.Sprintf(`%d %ss`, unit.qty, unit.name))
+		}
+	}
+
+	// Only the two largest units are worth mentioning
+	if len(parts) > 2 {
+		parts = parts[:2]
+	}
+
+	if len(parts) == 0 {
+		return `less than a minute`
+	}
+
+	return strings.Join(parts, `, `)
+}
@@ -0,0 +1,106 @@
+package usercommands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/volte6/gomud/factions"
+	"github.com/volte6/gomud/items"
+	"github.com/volte6/gomud/mobs"
+	"github.com/volte6/gomud/rooms"
+	"github.com/volte6/gomud/users"
+)
+
+// Repairs worn out items, either by paying a merchant who offers the service, or with the repair skill.
+func Repair(rest string, user *users.UserRecord, room *rooms.Room) (bool, error) {
+
+	for _, mobId := range room.GetMobs() {
+		if mob := mobs.GetInstance(mobId); mob != nil && mob.Repairer {
+			return repairService(rest, user, room, mob)
+		}
+	}
+
+	return repairSkill(rest, user, room)
+}
+
+// What a merchant charges a user to repair an item
+func repairPrice(itm items.Item, user *users.UserRecord, standing factions.Standing) int {
+	price := user.Character.BarterPrice(itm.RepairCost())
+	price = standing.BuyPrice(price)
+	if price < 1 {
+		price = 1
+	}
+	return price
+}
+
+func repairService(rest string, user *users.UserRecord, room *rooms.Room, mob *mobs.Mob) (bool, error) {
+
+	standing, _ := user.Character.GetFactionStanding(mob.Groups)
+	if !standing.WillTrade() {
+		mob.Command(`say I don't deal with the likes of you.`)
+		return true, nil
+	}
+
+	damagedItems := user.Character.GetDamagedItems()
+
+	if len(damagedItems) == 0 {
+		mob.Command(`say Your gear is in fine shape. Come back when you've given it a beating.`)
+		return true, nil
+	}
+
+	rest = strings.ToLower(strings.TrimSpace(rest))
+
+	if rest == `` {
+
+		mob.Command(`say Here's what it would cost to fix you up:`)
+
+		for _, itm := range damagedItems {
+			user.SendText(fmt.Sprintf(`  <ansi fg="itemname">%-30s</ansi> %3d/%-3d  <ansi fg="gold">%d gold</ansi>`, itm.NameSimple(), itm.Durability(), itm.MaxDurability(), repairPrice(itm, user, standing)))
+		}
+
+		user.SendText(`Type <ansi fg="command">repair [item]</ansi> or <ansi fg="command">repair all</ansi> to have them repaired.`)
+		return true, nil
+	}
+
+	toRepair := []items.Item{}
+
+	if rest == `all` {
+		toRepair = damagedItems
+	} else {
+
+		closeMatch, exactMatch := items.FindMatchIn(rest, damagedItems...)
+		if exactMatch.ItemId == 0 {
+			exactMatch = closeMatch
+		}
+
+		if exactMatch.ItemId == 0 {
+			user.SendText(fmt.Sprintf(`You don't have a damaged "%s".`, rest))
+			return true, nil
+		}
+
+		toRepair = append(toRepair, exactMatch)
+	}
+
+	totalPrice := 0
+	for _, itm := range toRepair {
+		totalPrice += repairPrice(itm, user, standing)
+	}
+
+	if totalPrice > user.Character.Gold {
+		mob.Command(fmt.Sprintf(`say That'll cost %d gold, which you don't seem to have.`, totalPrice))
+		return true, nil
+	}
+
+	user.Character.Gold -= totalPrice
+	mob.Character.Gold += totalPrice
+
+	for _, itm := range toRepair {
+		user.Character.RepairItem(itm, itm.Wear)
+		user.SendText(fmt.Sprintf(`<ansi fg="mobname">%s</ansi> repairs your <ansi fg="itemname">%s</ansi>.`, mob.Character.Name, itm.NameSimple()))
+	}
+
+	user.SendText(fmt.Sprintf(`You pay <ansi fg="gold">%d gold</ansi> for the repairs.`, totalPrice))
+	room.SendText(fmt.Sprintf(`<ansi fg="mobname">%s</ansi> repairs some of <ansi fg="username">%s</ansi>'s gear.`, mob.Character.Name, user.Character.Name), user.UserId)
+
+	return true, nil
+}
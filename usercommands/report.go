@@ -0,0 +1,68 @@
+package usercommands
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/volte6/gomud/chatlog"
+	"github.com/volte6/gomud/configs"
+	"github.com/volte6/gomud/rooms"
+	"github.com/volte6/gomud/users"
+)
+
+const (
+	reportCooldownTag = `report`
+)
+
+// Reports another player to the moderators, along with the chat leading up to it.
+func Report(rest string, user *users.UserRecord, room *rooms.Room) (bool, error) {
+
+	reportName, reason, _ := strings.Cut(strings.TrimSpace(rest), ` `)
+	reason = strings.TrimSpace(reason)
+
+	if reportName == `` || reason == `` {
+		user.SendText(`Type <ansi fg="command">report [player] [reason]</ansi>.`)
+		return true, nil
+	}
+
+	if rounds := user.Character.GetCooldown(reportCooldownTag); rounds > 0 {
+		user.SendText(fmt.Sprintf(`You've sent a report recently. You need to wait %d more rounds to send another.`, rounds))
+		return true, nil
+	}
+
+	reported := findPlayer(reportName)
+	if reported == nil {
+		user.SendText(fmt.Sprintf(`There's nobody named %s.`, reportName))
+		return true, nil
+	}
+
+	if reported.UserId == user.UserId {
+		user.SendText(`You can't report yourself.`)
+		return true, nil
+	}
+
+	c := configs.GetConfig()
+
+	r, err := chatlog.NewReport(room.RoomId, user.UserId, user.Character.Name, reported.UserId, reported.Character.Name, reason, int(c.ChatReportLines))
+	if err != nil {
+		slog.Error("Report()", "error", err)
+		user.SendText(`Your report could not be saved. Please let an admin know.`)
+		return true, nil
+	}
+
+	user.Character.TryCooldown(reportCooldownTag, c.MinutesToRounds(5))
+
+	user.SendText(fmt.Sprintf(`Your report about <ansi fg="username">%s</ansi> has been sent to the moderators. Thank you.`, reported.Character.Name))
+
+	for _, u := range users.GetAllActiveUsers() {
+		if u.Permission != users.PermissionAdmin && !u.HasAdminCommand(`chatlog`) {
+			continue
+		}
+		u.SendText(
+			fmt.Sprintf(`<ansi fg="alert-4">REPORT:</ansi> <ansi fg="username">%s</ansi> reported <ansi fg="username">%s</ansi>: %s - type <ansi fg="command">chatlog report %s</ansi> to review it.`, user.Character.Name, reported.Character.Name, reason, r.ReportId),
+		)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,54 @@
+package usercommands
+
+import (
+	"fmt"
+
+	"github.com/volte6/gomud/factions"
+	"github.com/volte6/gomud/rooms"
+	"github.com/volte6/gomud/templates"
+	"github.com/volte6/gomud/users"
+)
+
+func Reputation(rest string, user *users.UserRecord, room *rooms.Room) (bool, error) {
+
+	headers := []string{`Faction`, `Standing`, `Reputation`}
+	rows := [][]string{}
+	formatting := [][]string{}
+
+	for _, factionId := range factions.GetFactionIds() {
+
+		f := factions.GetFaction(factionId)
+
+		reputation, known := user.Character.Reputation[factionId]
+
+		// Secret factions stay that way until the player has dealt with them
+		if f.Secret && !known {
+			continue
+		}
+
+		standing := factions.GetStanding(reputation)
+
+		rows = append(rows, []string{
+			f.Name,
+			standing.String(),
+			fmt.Sprintf(`%d`, reputation),
+		})
+
+		formatting = append(formatting, []string{
+			`<ansi fg="factionname">%s</ansi>`,
+			`<ansi fg="` + standing.Color() + `">%s</ansi>`,
+			`<ansi fg="white-bold">%s</ansi>`,
+		})
+	}
+
+	if len(rows) == 0 {
+		user.SendText(`You haven't made a name for yourself with anyone yet.`)
+		return true, nil
+	}
+
+	reputationTableData := templates.GetTable(`Reputation`, headers, rows, formatting...)
+	reputationTxt, _ := templates.Process("tables/generic", reputationTableData)
+	user.SendText(reputationTxt)
+
+	return true, nil
+}
@@ -2,9 +2,13 @@ package usercommands
 
 import (
 	"fmt"
+	"math"
 	"strings"
+	"time"
 
 	"github.com/volte6/gomud/buffs"
+	"github.com/volte6/gomud/chatfilter"
+	"github.com/volte6/gomud/chatlog"
 	"github.com/volte6/gomud/rooms"
 	"github.com/volte6/gomud/users"
 	"github.com/volte6/gomud/util"
@@ -17,6 +21,11 @@ func Say(rest string, user *users.UserRecord, room *rooms.Room) (bool, error) {
 		return true, nil
 	}
 
+	var ok bool
+	if rest, ok = filterText(user, rest); !ok {
+		return true, nil
+	}
+
 	isSneaking := user.Character.HasBuffFlag(buffs.Hidden)
 	isDrunk := user.Character.HasBuffFlag(buffs.Drunk)
 
@@ -31,6 +40,14 @@ func Say(rest string, user *users.UserRecord, room *rooms.Room) (bool, error) {
 		room.SendTextCommunication(fmt.Sprintf(`<ansi fg="username">%s</ansi> says, "<ansi fg="saytext">%s</ansi>"`, user.Character.Name, rest), user.UserId)
 	}
 
+	chatlog.Log(chatlog.Entry{
+		Kind:       chatlog.KindSay,
+		RoomId:     room.RoomId,
+		FromUserId: user.UserId,
+		FromName:   user.Character.Name,
+		Text:       rest,
+	})
+
 	user.SendText(fmt.Sprintf(`You say, "<ansi fg="saytext">%s</ansi>"`, rest))
 
 	room.SendTextToExits(`You hear someone talking.`, true)
@@ -38,6 +55,35 @@ func Say(rest string, user *users.UserRecord, room *rooms.Room) (bool, error) {
 	return true, nil
 }
 
+// Runs player text through the chat filters, and tells them if it won't be sent.
+// Returns the text to send, and false if it shouldn't be sent at all.
+func filterText(user *users.UserRecord, text string) (string, bool) {
+
+	text, err := chatfilter.Check(user, text)
+
+	switch err {
+	case nil:
+		return text, true
+	case chatfilter.ErrFlooding:
+		user.SendText(`You're talking too quickly. Slow down a little.`)
+	case chatfilter.ErrRepeated:
+		user.SendText(`You've already said that.`)
+	case chatfilter.ErrBlocked:
+		user.SendText(`That isn't something you can say here.`)
+	case chatfilter.ErrAutoMuted:
+		minutes := int(math.Ceil(time.Until(user.MutedUntil).Minutes()))
+		if minutes == 1 {
+			user.SendText(`You have been <ansi fg="alert-5">MUTED</ansi> for a minute for spamming.`)
+		} else {
+			user.SendText(fmt.Sprintf(`You have been <ansi fg="alert-5">MUTED</ansi> for %d minutes for spamming.`, minutes))
+		}
+	default:
+		user.SendText(fmt.Sprintf(`Your message wasn't sent: %s`, err))
+	}
+
+	return text, false
+}
+
 func drunkify(sentence string) string {
 
 	var drunkSentence strings.Builder
@@ -46,7 +46,13 @@ func Sell(rest string, user *users.UserRecord, room *rooms.Room) (bool, error) {
 			continue
 		}
 
-		sellValue := mob.GetSellPrice(item)
+		standing, _ := user.Character.GetFactionStanding(mob.Groups)
+		if !standing.WillTrade() {
+			mob.Command(`say I don't deal with the likes of you.`)
+			continue
+		}
+
+		sellValue := standing.SellPrice(mob.GetSellPrice(item))
 
 		if sellValue <= 0 {
 
@@ -55,7 +61,7 @@ func Sell(rest string, user *users.UserRecord, room *rooms.Room) (bool, error) {
 			continue
 		}
 
-		user.Character.Gold += sellValue
+		user.EarnGold(sellValue)
 		user.Character.RemoveItem(item)
 
 		mob.Character.Shop.StockItem(item.ItemId)
@@ -74,6 +74,23 @@ func Set(rest string, user *users.UserRecord, room *rooms.Room) (bool, error) {
 	if setTarget == `description` {
 
 		rest = strings.TrimSpace(rest[len(setTarget):])
+
+		// Without a description, write one in the line editor
+		if rest == `` {
+			cmdPrompt, _ := user.StartPrompt(`set`, setTarget)
+			question := cmdPrompt.AskParagraph(`Describe your character`, user.Character.Description)
+			if !question.Done {
+				return true, nil
+			}
+			user.ClearPrompt()
+
+			if question.Response == `` {
+				user.SendText(`Your description was not changed.`)
+				return true, nil
+			}
+			rest = question.Response
+		}
+
 		if len(rest) > 1024 {
 			rest = rest[:1024]
 		}
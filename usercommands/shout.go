@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/volte6/gomud/buffs"
+	"github.com/volte6/gomud/chatlog"
 	"github.com/volte6/gomud/rooms"
 	"github.com/volte6/gomud/users"
 )
@@ -16,6 +17,11 @@ func Shout(rest string, user *users.UserRecord, room *rooms.Room) (bool, error)
 		return true, nil
 	}
 
+	var ok bool
+	if rest, ok = filterText(user, rest); !ok {
+		return true, nil
+	}
+
 	isSneaking := user.Character.HasBuffFlag(buffs.Hidden)
 	isDrunk := user.Character.HasBuffFlag(buffs.Drunk)
 
@@ -40,6 +46,14 @@ func Shout(rest string, user *users.UserRecord, room *rooms.Room) (bool, error)
 		}
 	}
 
+	chatlog.Log(chatlog.Entry{
+		Kind:       chatlog.KindShout,
+		RoomId:     room.RoomId,
+		FromUserId: user.UserId,
+		FromName:   user.Character.Name,
+		Text:       rest,
+	})
+
 	user.SendText(fmt.Sprintf(`You shout, "<ansi fg="yellow">%s</ansi>"`, rest))
 
 	return true, nil
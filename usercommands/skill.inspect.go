@@ -55,9 +55,12 @@ func Inspect(rest string, user *users.UserRecord, room *rooms.Room) (bool, error
 		)
 
 		type inspectDetails struct {
-			InspectLevel int
-			Item         *items.Item
-			ItemSpec     *items.ItemSpec
+			InspectLevel   int
+			Item           *items.Item
+			ItemSpec       *items.ItemSpec
+			ItemSet        *items.ItemSet
+			SetPiecesOwned int
+			SetPiecesWorn  int
 		}
 
 		iSpec := matchItem.GetSpec()
@@ -66,6 +69,11 @@ func Inspect(rest string, user *users.UserRecord, room *rooms.Room) (bool, error
 			InspectLevel: skillLevel,
 			Item:         &matchItem,
 			ItemSpec:     &iSpec,
+			ItemSet:      items.GetItemSetFor(matchItem.ItemId),
+		}
+
+		if details.ItemSet != nil {
+			details.SetPiecesOwned, details.SetPiecesWorn = user.Character.ItemSetPieces(details.ItemSet)
 		}
 
 		inspectTxt, _ := templates.Process("descriptions/inspect", details)
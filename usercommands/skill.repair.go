@@ -0,0 +1,90 @@
+package usercommands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/volte6/gomud/configs"
+	"github.com/volte6/gomud/items"
+	"github.com/volte6/gomud/rooms"
+	"github.com/volte6/gomud/skills"
+	"github.com/volte6/gomud/users"
+)
+
+/*
+Repair Skill
+Level 1 - Patch up weapons, restoring a quarter of their durability.
+Level 2 - Patch up armor and other worn gear too, restoring half of their durability.
+Level 3 - Mend broken items.
+Level 4 - Fully restore an item's durability.
+*/
+func repairSkill(rest string, user *users.UserRecord, room *rooms.Room) (bool, error) {
+
+	skillLevel := user.Character.GetSkillLevel(skills.Repair)
+
+	if skillLevel == 0 {
+		user.SendText(`There's nobody here who can repair things, and you don't know how.`)
+		return true, fmt.Errorf("you don't know how to repair")
+	}
+
+	rest = strings.ToLower(strings.TrimSpace(rest))
+
+	damagedItems := user.Character.GetDamagedItems()
+
+	if rest == `` {
+
+		if len(damagedItems) == 0 {
+			user.SendText(`Your gear is in fine shape.`)
+			return true, nil
+		}
+
+		user.SendText(`Your gear could use some work:`)
+		for _, itm := range damagedItems {
+			user.SendText(fmt.Sprintf(`  <ansi fg="itemname">%-30s</ansi> %3d/%-3d`, itm.NameSimple(), itm.Durability(), itm.MaxDurability()))
+		}
+		user.SendText(`Type <ansi fg="skill">repair [item]</ansi> to work on something.`)
+		return true, nil
+	}
+
+	closeMatch, matchItem := items.FindMatchIn(rest, damagedItems...)
+	if matchItem.ItemId == 0 {
+		matchItem = closeMatch
+	}
+
+	if matchItem.ItemId == 0 {
+		user.SendText(fmt.Sprintf(`You don't have a damaged "%s".`, rest))
+		return true, nil
+	}
+
+	if skillLevel < 2 && matchItem.GetSpec().Type != items.Weapon {
+		user.SendText(`You only know how to repair weapons.`)
+		return true, nil
+	}
+
+	if skillLevel < 3 && matchItem.IsBroken() {
+		user.SendText(fmt.Sprintf(`The <ansi fg="itemname">%s</ansi> is broken. You don't know how to mend it.`, matchItem.NameSimple()))
+		return true, nil
+	}
+
+	if !user.Character.TryCooldown(skills.Repair.String(), configs.GetConfig().MinutesToRounds(10)) {
+		user.SendText(
+			fmt.Sprintf("You need to wait %d more rounds to use that skill again.", user.Character.GetCooldown(skills.Repair.String())),
+		)
+		return true, errors.New(`you're doing that too often`)
+	}
+
+	repairAmt := matchItem.MaxDurability() / 4
+	if skillLevel >= 4 {
+		repairAmt = matchItem.MaxDurability()
+	} else if skillLevel >= 2 {
+		repairAmt = matchItem.MaxDurability() / 2
+	}
+
+	restored := user.Character.RepairItem(matchItem, repairAmt)
+
+	user.SendText(fmt.Sprintf(`You work on your <ansi fg="itemname">%s</ansi>, restoring <ansi fg="yellow">%d</ansi> durability.`, matchItem.NameSimple(), restored))
+	room.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> carefully works on their <ansi fg="itemname">%s</ansi>.`, user.Character.Name, matchItem.NameSimple()), user.UserId)
+
+	return true, nil
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/volte6/gomud/chatlog"
 	"github.com/volte6/gomud/items"
 	"github.com/volte6/gomud/rooms"
 	"github.com/volte6/gomud/skills"
@@ -66,9 +67,22 @@ func Scribe(rest string, user *users.UserRecord, room *rooms.Room) (bool, error)
 
 		} else {
 			// Write a sign in the room
+			var ok bool
+			if rest, ok = filterText(user, rest); !ok {
+				return true, nil
+			}
+
 			if len(rest) > 50 {
 				user.SendText("That won't fit! Keep it under 50 letters.")
 			} else {
+				chatlog.Log(chatlog.Entry{
+					Kind:       chatlog.KindSign,
+					RoomId:     room.RoomId,
+					FromUserId: user.UserId,
+					FromName:   user.Character.Name,
+					Text:       rest,
+				})
+
 				if replaced := room.AddSign(rest, 0, 7); replaced {
 					user.SendText("You knock down the old sign and replace it with a new one.")
 					room.SendText(
@@ -104,6 +118,14 @@ func Scribe(rest string, user *users.UserRecord, room *rooms.Room) (bool, error)
 			if len(rest) > 50 {
 				user.SendText("That won't fit! Keep it under 50 letters.")
 			} else {
+				chatlog.Log(chatlog.Entry{
+					Kind:       chatlog.KindSign,
+					RoomId:     room.RoomId,
+					FromUserId: user.UserId,
+					FromName:   user.Character.Name,
+					Text:       rest,
+				})
+
 				if replaced := room.AddSign(rest, user.UserId, 7); replaced {
 					user.SendText("You scratch out the old rune and replace it with a new one.")
 				} else {
@@ -112,7 +112,8 @@ func Backstab(rest string, user *users.UserRecord, room *rooms.Room) (bool, erro
 
 	} else if attackPlayerId > 0 {
 
-		if !configs.GetConfig().PVPEnabled {
+		// Arenas always allow PVP
+		if !bool(configs.GetConfig().PVPEnabled) && !room.IsArena {
 			user.SendText(`PVP is currently disabled.`)
 			return true, nil
 		}
@@ -8,6 +8,7 @@ import (
 	"github.com/volte6/gomud/configs"
 	"github.com/volte6/gomud/events"
 	"github.com/volte6/gomud/mobs"
+	"github.com/volte6/gomud/quests"
 	"github.com/volte6/gomud/rooms"
 	"github.com/volte6/gomud/skills"
 	"github.com/volte6/gomud/users"
@@ -87,7 +88,7 @@ func Pickpocket(rest string, user *users.UserRecord, room *rooms.Room) (bool, er
 					goldStolen := util.Rand(halfGold) + minGold
 					if goldStolen > 0 {
 						m.Character.Gold -= goldStolen
-						user.Character.Gold += goldStolen
+						user.EarnGold(goldStolen)
 						stolenStuff = append(stolenStuff, fmt.Sprintf(`<ansi fg="yellow-bold">%d gold</ansi>`, goldStolen))
 					}
 				}
@@ -189,6 +190,12 @@ func Pickpocket(rest string, user *users.UserRecord, room *rooms.Room) (bool, er
 
 					}
 
+					events.AddToQueue(events.QuestObjective{
+						UserId:        user.UserId,
+						ObjectiveType: string(quests.ObjectiveCollect),
+						ItemId:        itemStolen.ItemId,
+					})
+
 					stolenStuff = append(stolenStuff, fmt.Sprintf(`<ansi fg="itemname">%s</ansi>`, itemStolen.DisplayName()))
 				}
 
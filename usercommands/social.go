@@ -0,0 +1,81 @@
+package usercommands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/volte6/gomud/mobs"
+	"github.com/volte6/gomud/rooms"
+	"github.com/volte6/gomud/scripting"
+	"github.com/volte6/gomud/socials"
+	"github.com/volte6/gomud/users"
+)
+
+// Does a social such as smile or bow, to nobody in particular or to someone in the room.
+// Socials are sent without regard to Mute/Deafened (Not marked as a communication)
+// This is because they are pre-written.
+func doSocial(socialName string, social *socials.Social, rest string, user *users.UserRecord, room *rooms.Room) (bool, error) {
+
+	sourceName := fmt.Sprintf(`<ansi fg="username">%s</ansi>`, user.Character.Name)
+
+	if rest == `` {
+		user.SendText(socialText(social.NoTarget.Source, sourceName, ``))
+		room.SendText(socialText(social.NoTarget.Room, sourceName, ``), user.UserId)
+		return true, nil
+	}
+
+	playerId, mobInstanceId := room.FindByName(rest)
+
+	if playerId == user.UserId || strings.EqualFold(rest, `self`) || strings.EqualFold(rest, `me`) {
+		user.SendText(socialText(social.Self.Source, sourceName, ``))
+		room.SendText(socialText(social.Self.Room, sourceName, ``), user.UserId)
+		return true, nil
+	}
+
+	if social.Target == nil {
+		user.SendText(fmt.Sprintf(`You can't <ansi fg="command">%s</ansi> at someone.`, socialName))
+		return true, nil
+	}
+
+	if playerId > 0 {
+
+		if targetUser := users.GetByUserId(playerId); targetUser != nil {
+
+			targetName := fmt.Sprintf(`<ansi fg="username">%s</ansi>`, targetUser.Character.Name)
+
+			user.SendText(socialText(social.Target.Source, sourceName, targetName))
+
+			// Socials from ignored players are quietly dropped
+			if !targetUser.IsIgnoring(user.UserId) {
+				targetUser.SendText(socialText(social.Target.Target, sourceName, targetName))
+			}
+
+			room.SendText(socialText(social.Target.Room, sourceName, targetName), user.UserId, targetUser.UserId)
+
+			return true, nil
+		}
+	}
+
+	if mobInstanceId > 0 {
+
+		if mob := mobs.GetInstance(mobInstanceId); mob != nil {
+
+			targetName := fmt.Sprintf(`<ansi fg="mobname">%s</ansi>`, mob.Character.Name)
+
+			user.SendText(socialText(social.Target.Source, sourceName, targetName))
+			room.SendText(socialText(social.Target.Room, sourceName, targetName), user.UserId)
+
+			scripting.TryMobScriptEvent(`onSocial`, mob.InstanceId, user.UserId, `user`, map[string]any{`social`: socialName})
+
+			return true, nil
+		}
+	}
+
+	user.SendText(fmt.Sprintf(`You don't see %s here.`, rest))
+
+	return true, nil
+}
+
+func socialText(msg string, sourceName string, targetName string) string {
+	return `<ansi fg="20">` + socials.Format(msg, sourceName, targetName) + `</ansi>`
+}
@@ -0,0 +1,119 @@
+package usercommands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/volte6/gomud/configs"
+	"github.com/volte6/gomud/pets"
+	"github.com/volte6/gomud/rooms"
+	"github.com/volte6/gomud/term"
+	"github.com/volte6/gomud/users"
+	"github.com/volte6/gomud/util"
+)
+
+func Stable(rest string, user *users.UserRecord, room *rooms.Room) (bool, error) {
+
+	user.SendText(``)
+
+	if !room.IsStable {
+		user.SendText(`You are not at a stable.` + term.CRLFStr)
+		return true, nil
+	}
+
+	c := configs.GetConfig()
+	args := util.SplitButRespectQuotes(strings.ToLower(rest))
+
+	if len(args) == 0 {
+
+		if len(user.Character.StabledPets) == 0 {
+			user.SendText(`You have no pets waiting at the stables.`)
+		} else {
+			user.SendText(`Your pets waiting at the stables:`)
+			for _, stabledPet := range user.Character.StabledPets {
+				user.SendText(fmt.Sprintf(`  %s (%s)`, stabledPet.DisplayName(), stabledPet.Type))
+			}
+		}
+
+		user.SendText(fmt.Sprintf(`You can <ansi fg="command">stable leave</ansi> your pet for <ansi fg="gold">%d gold</ansi>, or <ansi fg="command">stable collect</ansi> a pet that is waiting here.`+term.CRLFStr, c.StableFee))
+		return true, nil
+	}
+
+	switch args[0] {
+
+	case `leave`:
+
+		if !user.Character.Pet.Exists() {
+			user.SendText(`You don't have a pet with you.` + term.CRLFStr)
+			return true, nil
+		}
+
+		if len(user.Character.StabledPets) >= int(c.StableMaxPets) {
+			user.SendText(fmt.Sprintf(`The stables can only look after %d of your pets at a time.%s`, c.StableMaxPets, term.CRLFStr))
+			return true, nil
+		}
+
+		if user.Character.Gold < int(c.StableFee) {
+			user.SendText(fmt.Sprintf(`It costs <ansi fg="gold">%d gold</ansi> to leave a pet here. You don't have enough.%s`, c.StableFee, term.CRLFStr))
+			return true, nil
+		}
+
+		user.Character.Gold -= int(c.StableFee)
+
+		stabledPet := user.Character.Pet
+		stabledPet.Mounted = false
+
+		user.Character.StabledPets = append(user.Character.StabledPets, stabledPet)
+		user.Character.Pet = pets.Pet{}
+		// make sure the pet buffs are removed
+		user.Character.Validate(true)
+
+		user.SendText(fmt.Sprintf(`You pay <ansi fg="gold">%d gold</ansi> and leave %s in the care of the stables.%s`, c.StableFee, stabledPet.DisplayName(), term.CRLFStr))
+		room.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> leaves %s at the stables.`, user.Character.Name, stabledPet.DisplayName()), user.UserId)
+
+	case `collect`:
+
+		if len(user.Character.StabledPets) == 0 {
+			user.SendText(`You have no pets waiting at the stables.` + term.CRLFStr)
+			return true, nil
+		}
+
+		if user.Character.Pet.Exists() {
+			user.SendText(fmt.Sprintf(`You already have %s with you. You'll need to <ansi fg="command">stable leave</ansi> it first.%s`, user.Character.Pet.DisplayName(), term.CRLFStr))
+			return true, nil
+		}
+
+		// With no name given, collect the first pet waiting
+		petIdx := 0
+		if len(args) > 1 {
+			petName := strings.Join(args[1:], ` `)
+			petIdx = -1
+			for i, stabledPet := range user.Character.StabledPets {
+				if strings.EqualFold(stabledPet.Name, petName) || strings.EqualFold(stabledPet.Type, petName) {
+					petIdx = i
+					break
+				}
+			}
+			if petIdx == -1 {
+				user.SendText(fmt.Sprintf(`There's no "%s" waiting for you at the stables.%s`, petName, term.CRLFStr))
+				return true, nil
+			}
+		}
+
+		collectedPet := user.Character.StabledPets[petIdx]
+		collectedPet.Health = collectedPet.HealthMax // Well rested
+
+		user.Character.StabledPets = append(user.Character.StabledPets[:petIdx], user.Character.StabledPets[petIdx+1:]...)
+		user.Character.Pet = collectedPet
+		// make sure pet buffs get applied
+		user.Character.Validate(true)
+
+		user.SendText(fmt.Sprintf(`A stablehand brings out %s, well rested and ready to go.%s`, collectedPet.DisplayName(), term.CRLFStr))
+		room.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> collects %s from the stables.`, user.Character.Name, collectedPet.DisplayName()), user.UserId)
+
+	default:
+		user.SendText(`Try <ansi fg="command">help stable</ansi> for more information about stables.` + term.CRLFStr)
+	}
+
+	return true, nil
+}
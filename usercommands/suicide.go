@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"math"
 
+	"github.com/volte6/gomud/achievements"
 	"github.com/volte6/gomud/buffs"
 	"github.com/volte6/gomud/characters"
 	"github.com/volte6/gomud/colorpatterns"
 	"github.com/volte6/gomud/configs"
+	"github.com/volte6/gomud/discord"
 	"github.com/volte6/gomud/events"
 	"github.com/volte6/gomud/rooms"
 	"github.com/volte6/gomud/templates"
@@ -41,6 +43,8 @@ func Suicide(rest string, user *users.UserRecord, room *rooms.Room) (bool, error
 		Text: fmt.Sprintf(`<ansi fg="magenta-bold">***</ansi> <ansi fg="username">%s</ansi> has <ansi fg="red-bold">DIED!</ansi> <ansi fg="magenta-bold">***</ansi>`, user.Character.Name),
 	})
 
+	discord.Mirror(discord.MirrorDeaths, ``, fmt.Sprintf(`%s has died!`, user.Character.Name))
+
 	// If permadeath is enabled, do some extra bookkeeping
 	if config.PermaDeath {
 
@@ -127,6 +131,11 @@ func Suicide(rest string, user *users.UserRecord, room *rooms.Room) (bool, error
 
 	user.Character.KD.AddDeath()
 
+	events.AddToQueue(events.Achievement{
+		UserId:       user.UserId,
+		CriteriaType: string(achievements.CriteriaDeaths),
+	})
+
 	rooms.MoveToRoom(user.UserId, 75)
 
 	return true, nil
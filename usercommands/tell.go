@@ -0,0 +1,137 @@
+package usercommands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/volte6/gomud/chatlog"
+	"github.com/volte6/gomud/configs"
+	"github.com/volte6/gomud/rooms"
+	"github.com/volte6/gomud/users"
+	"github.com/volte6/gomud/util"
+)
+
+// Sends a message to a player anywhere in the world.
+// If they are offline it waits for them until they log in.
+func Tell(rest string, user *users.UserRecord, room *rooms.Room) (bool, error) {
+
+	args := util.SplitButRespectQuotes(rest)
+
+	if len(args) < 1 {
+		user.SendText(`Tell who what? Type <ansi fg="command">tell [player] [message]</ansi>.`)
+		return true, nil
+	}
+
+	// Deliver anything that was sent while they were away
+	if len(args) == 1 && strings.EqualFold(args[0], `check`) {
+		tellCheck(user)
+		return true, nil
+	}
+
+	tellName := args[0]
+	if len(rest) < len(tellName)+1 {
+		user.SendText("You need to specify a message.")
+		return true, nil
+	}
+
+	rest = strings.TrimSpace(rest[len(tellName)+1:])
+
+	toUser := findPlayer(tellName)
+	if toUser == nil {
+		user.SendText("You can't find anyone by that name.")
+		return true, nil
+	}
+
+	if toUser.UserId == user.UserId {
+		user.SendText(`You mutter to yourself.`)
+		return true, nil
+	}
+
+	sourceIsMod := user.Permission == users.PermissionAdmin || user.Permission == users.PermissionMod
+	targetIsMod := toUser.Permission == users.PermissionAdmin || toUser.Permission == users.PermissionMod
+
+	if user.Muted && !targetIsMod {
+		user.SendText(`You are <ansi fg="alert-5">MUTED</ansi>. You can only send <ansi fg="command">tell</ansi>'s to Admins and Moderators.`)
+		return true, nil
+	}
+
+	if toUser.Deafened && !sourceIsMod {
+		user.SendText(`That user is <ansi fg="alert-5">DEAFENED</ansi> and cannot receive communications from other players.`)
+		return true, nil
+	}
+
+	// Players can always reach the moderators, even after tripping the filters
+	if !targetIsMod {
+		var ok bool
+		if rest, ok = filterText(user, rest); !ok {
+			return true, nil
+		}
+	}
+
+	chatlog.Log(chatlog.Entry{
+		Kind:       chatlog.KindTell,
+		RoomId:     user.Character.RoomId,
+		FromUserId: user.UserId,
+		FromName:   user.Character.Name,
+		ToUserId:   toUser.UserId,
+		ToName:     toUser.Character.Name,
+		Text:       rest,
+	})
+
+	isOnline := users.GetByUserId(toUser.UserId) != nil
+
+	// Tells from ignored players are quietly dropped
+	if toUser.IsIgnoring(user.UserId) && !sourceIsMod {
+		if isOnline {
+			user.SendText(fmt.Sprintf(`You tell <ansi fg="username">%s</ansi>, "<ansi fg="saytext">%s</ansi>"`, toUser.Character.Name, rest))
+		} else {
+			user.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> is offline. They'll get your message when they return.`, toUser.Character.Name))
+		}
+		return true, nil
+	}
+
+	if isOnline {
+		toUser.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> tells you, "<ansi fg="saytext">%s</ansi>"`, user.Character.Name, rest))
+		user.SendText(fmt.Sprintf(`You tell <ansi fg="username">%s</ansi>, "<ansi fg="saytext">%s</ansi>"`, toUser.Character.Name, rest))
+		return true, nil
+	}
+
+	queued := toUser.QueueTell(users.QueuedTell{
+		FromUserId: user.UserId,
+		FromName:   user.Character.Name,
+		Message:    rest,
+	})
+
+	if !queued {
+		user.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> is offline, and already has too many messages waiting.`, toUser.Character.Name))
+		return true, nil
+	}
+
+	users.SaveUser(*toUser)
+
+	user.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> is offline. They'll get your message when they return.`, toUser.Character.Name))
+
+	return true, nil
+}
+
+func tellCheck(user *users.UserRecord) {
+
+	tells := []users.QueuedTell{}
+	for _, t := range user.TakeQueuedTells() {
+		// They may have been ignored since
+		if !user.IsIgnoring(t.FromUserId) {
+			tells = append(tells, t)
+		}
+	}
+
+	if len(tells) == 0 {
+		return
+	}
+
+	tFormat := string(configs.GetConfig().TimeFormat)
+
+	user.SendText(`<ansi fg="159">While you were away:</ansi>`)
+	for _, t := range tells {
+		user.SendText(fmt.Sprintf(`<ansi fg="black-bold">[%s]</ansi> <ansi fg="username">%s</ansi> told you, "<ansi fg="saytext">%s</ansi>"`, t.Sent.Format(tFormat), t.FromName, t.Message))
+	}
+}
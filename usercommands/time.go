@@ -2,8 +2,10 @@ package usercommands
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/volte6/gomud/gametime"
+	"github.com/volte6/gomud/moons"
 	"github.com/volte6/gomud/rooms"
 	"github.com/volte6/gomud/users"
 )
@@ -31,5 +33,15 @@ func Time(rest string, user *users.UserRecord, room *rooms.Room) (bool, error) {
 		gametime.GetZodiac(gd.Year),
 	))
 
+	moonPhases := []string{}
+	for _, moonId := range moons.GetMoonIds() {
+		m := moons.GetMoon(moonId)
+		moonPhases = append(moonPhases, fmt.Sprintf(`<ansi fg="230">%s</ansi> is <ansi fg="night">%s</ansi>`, m.Name, strings.ReplaceAll(m.GetPhaseName(gd), `-`, ` `)))
+	}
+
+	if len(moonPhases) > 0 {
+		user.SendText(`Overhead, ` + strings.Join(moonPhases, `, `) + `.`)
+	}
+
 	return true, nil
 }
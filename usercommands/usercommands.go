@@ -6,9 +6,11 @@ import (
 	"time"
 
 	"github.com/volte6/gomud/buffs"
+	"github.com/volte6/gomud/channels"
 	"github.com/volte6/gomud/keywords"
 	"github.com/volte6/gomud/rooms"
 	"github.com/volte6/gomud/scripting"
+	"github.com/volte6/gomud/socials"
 	"github.com/volte6/gomud/users"
 	"github.com/volte6/gomud/util"
 )
@@ -28,125 +30,144 @@ type CommandAccess struct {
 
 var (
 	userCommands map[string]CommandAccess = map[string]CommandAccess{
-		`aid`:         {Aid, false, false},
-		`alias`:       {Alias, true, false},
-		`appraise`:    {Appraise, false, false},
-		`ask`:         {Ask, false, false},
-		`attack`:      {Attack, false, false},
-		`auction`:     {Auction, true, false},
-		`backstab`:    {Backstab, false, false},
-		`badcommands`: {BadCommands, true, true}, // Admin only
-		`biome`:       {Biome, true, false},
-		`broadcast`:   {Broadcast, true, false},
-		`character`:   {Character, true, false},
-		`tackle`:      {Tackle, false, false},
-		`bank`:        {Bank, false, false},
-		`break`:       {Break, false, false},
-		`build`:       {Build, false, true},  // Admin only
-		`ibuild`:      {IBuild, false, true}, // Admin only
-		`buff`:        {Buff, false, true},   // Admin only
-		`bump`:        {Bump, false, false},
-		`buy`:         {Buy, false, false},
-		`cast`:        {Cast, false, false},
-		`cooldowns`:   {Cooldowns, true, false},
-		`command`:     {Command, false, true}, // Admin only
-		`conditions`:  {Conditions, true, false},
-		`consider`:    {Consider, true, false},
-		`deafen`:      {Deafen, true, true}, // Admin only
-		`default`:     {Default, false, false},
-		`disarm`:      {Disarm, false, false},
-		`drop`:        {Drop, true, false},
-		`drink`:       {Drink, false, false},
-		`eat`:         {Eat, false, false},
-		`emote`:       {Emote, true, false},
-		`enchant`:     {Enchant, false, false},
-		`exits`:       {Exits, true, false},
-		`experience`:  {Experience, true, false},
-		`equip`:       {Equip, false, false},
-		`flee`:        {Flee, false, false},
-		`follow`:      {Follow, false, false},
-		`gearup`:      {Gearup, false, false},
-		`get`:         {Get, false, false},
-		`give`:        {Give, false, false},
-		`go`:          {Go, false, false},
-		`help`:        {Help, true, false},
-		`keyring`:     {KeyRing, true, false},
-		`killstats`:   {Killstats, true, false},
-		`inbox`:       {Inbox, true, false},
-		`inspect`:     {Inspect, false, false},
-		`inventory`:   {Inventory, true, false},
-		`jobs`:        {Jobs, true, false},
-		`list`:        {List, false, false},
-		`locate`:      {Locate, true, true}, // Admin only
-		`lock`:        {Lock, false, false},
-		`look`:        {Look, true, false},
-		`map`:         {Map, false, false},
-		`mudmail`:     {Mudmail, true, true}, // Admin only
-		`macros`:      {Macros, true, false},
-		`modify`:      {Modify, true, true}, // Admin only
-		`motd`:        {Motd, true, false},
-		`mute`:        {Mute, true, true},
-		`offer`:       {Offer, false, false},
-		`online`:      {Online, true, false},
-		`party`:       {Party, true, false},
-		`password`:    {Password, true, false},
-		`peep`:        {Peep, false, false},
-		`pet`:         {Pet, false, false},
-		`picklock`:    {Picklock, false, false},
-		`pickpocket`:  {Pickpocket, false, false},
-		`prepare`:     {Prepare, true, true}, // Admin only
-		`portal`:      {Portal, false, false},
-		`pray`:        {Pray, false, false},
-		`print`:       {Print, true, false},
-		`put`:         {Put, false, false},
-		`quests`:      {Quests, true, false},
-		`quit`:        {Quit, true, false},
-		`questtoken`:  {QuestToken, false, true}, // Admin only
-		`rank`:        {Rank, false, false},
-		`read`:        {Read, false, false},
-		`recover`:     {Recover, false, false},
-		`reload`:      {Reload, true, true}, // Admin only
-		`remove`:      {Remove, false, false},
-		`rename`:      {Rename, false, true},     // Admin only
-		`redescribe`:  {Redescribe, false, true}, // Admin only
-		`room`:        {Room, false, true},       // Admin only
-		`save`:        {Save, true, false},
-		`say`:         {Say, true, false},
-		`scribe`:      {Scribe, false, false},
-		`search`:      {Search, false, false},
-		`sell`:        {Sell, false, false},
-		`server`:      {Server, false, true}, // Admin only
-		`set`:         {Set, true, false},
-		`share`:       {Share, false, false},
-		`shoot`:       {Shoot, false, false},
-		`shout`:       {Shout, true, false},
-		`show`:        {Show, true, false},
-		`skills`:      {Skills, true, false},
-		`skillset`:    {Skillset, false, true}, // Admin only
-		`sneak`:       {Sneak, false, false},
-		`spawn`:       {Spawn, false, true}, // Admin only
-		`spells`:      {Spells, true, false},
-		`stash`:       {Stash, false, false},
-		`status`:      {Status, true, false},
-		`storage`:     {Storage, false, false},
-		`suicide`:     {Suicide, true, false},
-		`tame`:        {Tame, false, false},
-		`time`:        {Time, true, false},
-		`throw`:       {Throw, false, false},
-		`track`:       {Track, false, false},
-		`trash`:       {Trash, false, false},
-		`train`:       {Train, false, false},
-		`unenchant`:   {Unenchant, false, false},
-		`uncurse`:     {Uncurse, false, false},
-		`unlock`:      {Unlock, false, false},
-		`undeafen`:    {UnDeafen, true, true}, // Admin only
-		`unmute`:      {UnMute, true, true},   // Admin only
-		`use`:         {Use, false, false},
-		`dual-wield`:  {DualWield, true, false},
-		`whisper`:     {Whisper, true, false},
-		`who`:         {Who, true, false},
-		`zap`:         {Zap, false, true},  // Admin only
-		`zone`:        {Zone, false, true}, // Admin only
+		`achievements`: {Achievements, true, false},
+		`aid`:          {Aid, false, false},
+		`alias`:        {Alias, true, false},
+		`appraise`:     {Appraise, false, false},
+		`ask`:          {Ask, false, false},
+		`attack`:       {Attack, false, false},
+		`auction`:      {Auction, true, false},
+		`backstab`:     {Backstab, false, false},
+		`badcommands`:  {BadCommands, true, true}, // Admin only
+		`biome`:        {Biome, true, false},
+		`board`:        {Board, false, false},
+		`broadcast`:    {Broadcast, true, false},
+		`character`:    {Character, true, false},
+		`channel`:      {Channel, true, false},
+		`chatlog`:      {ChatLog, true, true}, // Admin only
+		`tackle`:       {Tackle, false, false},
+		`tell`:         {Tell, true, false},
+		`bank`:         {Bank, false, false},
+		`break`:        {Break, false, false},
+		`build`:        {Build, false, true},  // Admin only
+		`ibuild`:       {IBuild, false, true}, // Admin only
+		`buff`:         {Buff, false, true},   // Admin only
+		`bump`:         {Bump, false, false},
+		`buy`:          {Buy, false, false},
+		`cast`:         {Cast, false, false},
+		`cooldowns`:    {Cooldowns, true, false},
+		`command`:      {Command, false, true}, // Admin only
+		`conditions`:   {Conditions, true, false},
+		`consider`:     {Consider, true, false},
+		`deafen`:       {Deafen, true, true}, // Admin only
+		`default`:      {Default, false, false},
+		`disarm`:       {Disarm, false, false},
+		`dismount`:     {Dismount, false, false},
+		`drop`:         {Drop, true, false},
+		`drink`:        {Drink, false, false},
+		`duel`:         {Duel, false, false},
+		`eat`:          {Eat, false, false},
+		`emote`:        {Emote, true, false},
+		`enchant`:      {Enchant, false, false},
+		`exits`:        {Exits, true, false},
+		`experience`:   {Experience, true, false},
+		`equip`:        {Equip, false, false},
+		`flee`:         {Flee, false, false},
+		`follow`:       {Follow, false, false},
+		`friend`:       {Friend, true, false},
+		`gearup`:       {Gearup, false, false},
+		`get`:          {Get, false, false},
+		`give`:         {Give, false, false},
+		`go`:           {Go, false, false},
+		`help`:         {Help, true, false},
+		`ignore`:       {Ignore, true, false},
+		`house`:        {House, false, false},
+		`keyring`:      {KeyRing, true, false},
+		`killstats`:    {Killstats, true, false},
+		`inbox`:        {Inbox, true, false},
+		`inspect`:      {Inspect, false, false},
+		`inventory`:    {Inventory, true, false},
+		`jobs`:         {Jobs, true, false},
+		`ladder`:       {Ladder, true, false},
+		`leaderboard`:  {Leaderboard, true, false},
+		`list`:         {List, false, false},
+		`locate`:       {Locate, true, true}, // Admin only
+		`lock`:         {Lock, false, false},
+		`look`:         {Look, true, false},
+		`map`:          {Map, false, false},
+		`mudmail`:      {Mudmail, true, true}, // Admin only
+		`macros`:       {Macros, true, false},
+		`mail`:         {Mail, false, false},
+		`modify`:       {Modify, true, true}, // Admin only
+		`motd`:         {Motd, true, false},
+		`mount`:        {Mount, false, false},
+		`mute`:         {Mute, true, true},
+		`offer`:        {Offer, false, false},
+		`online`:       {Online, true, false},
+		`party`:        {Party, true, false},
+		`password`:     {Password, true, false},
+		`peep`:         {Peep, false, false},
+		`pet`:          {Pet, false, false},
+		`picklock`:     {Picklock, false, false},
+		`pickpocket`:   {Pickpocket, false, false},
+		`prepare`:      {Prepare, true, true}, // Admin only
+		`portal`:       {Portal, false, false},
+		`pray`:         {Pray, false, false},
+		`print`:        {Print, true, false},
+		`put`:          {Put, false, false},
+		`quests`:       {Quests, true, false},
+		`questboard`:   {Questboard, false, false},
+		`quit`:         {Quit, true, false},
+		`questtoken`:   {QuestToken, false, true}, // Admin only
+		`rank`:         {Rank, false, false},
+		`read`:         {Read, false, false},
+		`recover`:      {Recover, false, false},
+		`reload`:       {Reload, true, true}, // Admin only
+		`remove`:       {Remove, false, false},
+		`repair`:       {Repair, false, false},
+		`report`:       {Report, true, false},
+		`reputation`:   {Reputation, true, false},
+		`rename`:       {Rename, false, true},     // Admin only
+		`redescribe`:   {Redescribe, false, true}, // Admin only
+		`room`:         {Room, false, true},       // Admin only
+		`save`:         {Save, true, false},
+		`say`:          {Say, true, false},
+		`scribe`:       {Scribe, false, false},
+		`search`:       {Search, false, false},
+		`sell`:         {Sell, false, false},
+		`server`:       {Server, false, true}, // Admin only
+		`set`:          {Set, true, false},
+		`share`:        {Share, false, false},
+		`shoot`:        {Shoot, false, false},
+		`shout`:        {Shout, true, false},
+		`show`:         {Show, true, false},
+		`skills`:       {Skills, true, false},
+		`skillset`:     {Skillset, false, true}, // Admin only
+		`sneak`:        {Sneak, false, false},
+		`spawn`:        {Spawn, false, true}, // Admin only
+		`spells`:       {Spells, true, false},
+		`stable`:       {Stable, false, false},
+		`stash`:        {Stash, false, false},
+		`status`:       {Status, true, false},
+		`storage`:      {Storage, false, false},
+		`suicide`:      {Suicide, true, false},
+		`tame`:         {Tame, false, false},
+		`time`:         {Time, true, false},
+		`throw`:        {Throw, false, false},
+		`track`:        {Track, false, false},
+		`trash`:        {Trash, false, false},
+		`train`:        {Train, false, false},
+		`unenchant`:    {Unenchant, false, false},
+		`uncurse`:      {Uncurse, false, false},
+		`unlock`:       {Unlock, false, false},
+		`undeafen`:     {UnDeafen, true, true}, // Admin only
+		`unmute`:       {UnMute, true, true},   // Admin only
+		`use`:          {Use, false, false},
+		`dual-wield`:   {DualWield, true, false},
+		`whisper`:      {Whisper, true, false},
+		`who`:          {Who, true, false},
+		`zap`:          {Zap, false, true},  // Admin only
+		`zone`:         {Zone, false, true}, // Admin only
 		// Special command only used upon creating a new account
 		`start`: {Start, false, false},
 	}
@@ -310,9 +331,16 @@ func TryCommand(cmd string, rest string, userId int) (bool, error) {
 	}
 	// end "go" attempt
 
-	if _, ok := emoteAliases[cmd]; ok {
-		handled, err := Emote(cmd, user, room)
-		return handled, err
+	// Socials, such as "smile" or "bow bobbin"
+	if social := socials.Get(cmd); social != nil {
+		return doSocial(cmd, social, rest, user, room)
+	}
+
+	// Talking on a chat channel, such as "newbie hello"
+	if ch := channels.Get(cmd); ch != nil {
+		if handled, err := channelTalk(ch, rest, user); handled {
+			return handled, err
+		}
 	}
 
 	if user.Character.HasSpell(cmd) {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/volte6/gomud/chatlog"
 	"github.com/volte6/gomud/rooms"
 	"github.com/volte6/gomud/users"
 	"github.com/volte6/gomud/util"
@@ -46,6 +47,30 @@ func Whisper(rest string, user *users.UserRecord, room *rooms.Room) (bool, error
 		return true, nil
 	}
 
+	// Players can always reach the moderators, even after tripping the filters
+	if !targetIsMod {
+		var ok bool
+		if rest, ok = filterText(user, rest); !ok {
+			return true, nil
+		}
+	}
+
+	chatlog.Log(chatlog.Entry{
+		Kind:       chatlog.KindWhisper,
+		RoomId:     room.RoomId,
+		FromUserId: user.UserId,
+		FromName:   user.Character.Name,
+		ToUserId:   toUser.UserId,
+		ToName:     toUser.Character.Name,
+		Text:       rest,
+	})
+
+	// Whispers from ignored players are quietly dropped
+	if toUser.IsIgnoring(user.UserId) && !sourceIsMod {
+		user.SendText(fmt.Sprintf(`You sent a <ansi fg="command">whisper</ansi> to <ansi fg="username">%s</ansi>`, toUser.Character.Name))
+		return true, nil
+	}
+
 	toUser.SendText(fmt.Sprintf(`<ansi fg="white">***</ansi> <ansi fg="black-bold"><ansi fg="username">%s</ansi> whispers, "%s"</ansi> <ansi fg="white">***</ansi>`, user.Character.Name, rest))
 
 	user.SendText(fmt.Sprintf(`You sent a <ansi fg="command">whisper</ansi> to <ansi fg="username">%s</ansi>`, toUser.Character.Name))
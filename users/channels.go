@@ -0,0 +1,53 @@
+package users
+
+import "slices"
+
+const (
+	ChannelJoined = `joined`
+	ChannelLeft   = `left`
+	ChannelMuted  = `muted` // Still joined, but not shown any messages
+)
+
+// Returns whether the user has joined, left or muted a chat channel
+func (u *UserRecord) GetChannelState(channelId string, autoJoin bool) string {
+	if state, ok := u.Channels[channelId]; ok {
+		return state
+	}
+	if autoJoin {
+		return ChannelJoined
+	}
+	return ChannelLeft
+}
+
+func (u *UserRecord) SetChannelState(channelId string, state string) {
+	if u.Channels == nil {
+		u.Channels = map[string]string{}
+	}
+	u.Channels[channelId] = state
+}
+
+// Whether the user is blocked from talking on a channel
+func (u *UserRecord) IsMutedOn(channelId string) bool {
+	return u.Muted || slices.Contains(u.MutedOn, channelId)
+}
+
+// Whether the user is blocked from hearing other players on a channel
+func (u *UserRecord) IsDeafenedOn(channelId string) bool {
+	return u.Deafened || slices.Contains(u.DeafenedOn, channelId)
+}
+
+// Mutes or unmutes the user on a single channel
+func (u *UserRecord) SetMutedOn(channelId string, muted bool) {
+	u.MutedOn = slices.DeleteFunc(u.MutedOn, func(c string) bool { return c == channelId })
+	if muted {
+		u.MutedOn = append(u.MutedOn, channelId)
+	}
+}
+
+// Deafens or undeafens the user on a single channel
+func (u *UserRecord) SetDeafenedOn(channelId string, deafened bool) {
+	u.DeafenedOn = slices.DeleteFunc(u.DeafenedOn, func(c string) bool { return c == channelId })
+	if deafened {
+		u.DeafenedOn = append(u.DeafenedOn, channelId)
+	}
+}
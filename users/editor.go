@@ -0,0 +1,76 @@
+package users
+
+import (
+	"github.com/volte6/gomud/connections"
+	"github.com/volte6/gomud/editor"
+)
+
+// Opens or closes the line editor to match their prompt.
+// If the question they're on is multi-line, the editor takes over their input until they finish it.
+// If they've reconnected since it was opened, it moves over to their new connection.
+func (u *UserRecord) SyncEditor() {
+
+	if u.activePrompt != nil {
+		if q := u.activePrompt.GetNextQuestion(); q != nil && q.IsMultiLine() {
+			if u.activeEditor == nil {
+				u.openEditor(q.Question, q.DefaultResponse, q.JoinsLines())
+			} else if u.editorConnId != u.connectionId {
+				u.attachEditor()
+			}
+			return
+		}
+	}
+
+	u.closeEditor()
+}
+
+// Returns what they wrote in the line editor, and closes it.
+// Returns false if they're still writing.
+func (u *UserRecord) EditorText() (string, bool) {
+
+	if u.activeEditor == nil {
+		return ``, false
+	}
+
+	text, finished := u.activeEditor.Text()
+	if finished {
+		u.closeEditor()
+	}
+
+	return text, finished
+}
+
+func (u *UserRecord) openEditor(title string, text string, joinLines bool) {
+
+	u.activeEditor = editor.New(u.UserId, title, text, joinLines)
+	u.attachEditor()
+}
+
+// Puts the editor on their current connection, and shows them where they are in it
+func (u *UserRecord) attachEditor() {
+
+	cd := connections.Get(u.connectionId)
+	if cd == nil {
+		return
+	}
+
+	// Ahead of the admin and system command handlers, so the editors own / commands reach it
+	cd.AddInputHandler(editor.InputHandlerName, u.activeEditor.InputHandler, `HistoryInputHandler`)
+	u.editorConnId = u.connectionId
+
+	u.activeEditor.Start()
+}
+
+func (u *UserRecord) closeEditor() {
+
+	if u.activeEditor == nil {
+		return
+	}
+
+	u.activeEditor = nil
+
+	if cd := connections.Get(u.editorConnId); cd != nil {
+		cd.RemoveInputHandler(editor.InputHandlerName)
+	}
+	u.editorConnId = 0
+}
@@ -0,0 +1,48 @@
+package users
+
+import "slices"
+
+func (u *UserRecord) IsFriend(userId int) bool {
+	return slices.Contains(u.Friends, userId)
+}
+
+// Returns false if they were already a friend
+func (u *UserRecord) AddFriend(userId int) bool {
+	if u.IsFriend(userId) {
+		return false
+	}
+	u.Friends = append(u.Friends, userId)
+	return true
+}
+
+// Returns false if they weren't a friend
+func (u *UserRecord) RemoveFriend(userId int) bool {
+	if !u.IsFriend(userId) {
+		return false
+	}
+	u.Friends = slices.DeleteFunc(u.Friends, func(id int) bool { return id == userId })
+	return true
+}
+
+// Whether the user doesn't want to receive communications from another user
+func (u *UserRecord) IsIgnoring(userId int) bool {
+	return slices.Contains(u.Ignoring, userId)
+}
+
+// Returns false if they were already ignored
+func (u *UserRecord) Ignore(userId int) bool {
+	if u.IsIgnoring(userId) {
+		return false
+	}
+	u.Ignoring = append(u.Ignoring, userId)
+	return true
+}
+
+// Returns false if they weren't ignored
+func (u *UserRecord) Unignore(userId int) bool {
+	if !u.IsIgnoring(userId) {
+		return false
+	}
+	u.Ignoring = slices.DeleteFunc(u.Ignoring, func(id int) bool { return id == userId })
+	return true
+}
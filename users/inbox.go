@@ -15,7 +15,9 @@ type Message struct {
 	Message    string
 	Item       *items.Item
 	Gold       int
+	COD        int `yaml:"cod,omitempty"` // Gold the recipient must pay before they receive the attachments
 	Read       bool
+	Archived   bool `yaml:"archived,omitempty"`
 	DateSent   time.Time
 }
 
@@ -52,11 +54,81 @@ func (i *Inbox) CountUnread() int {
 	return ct
 }
 
+// Letters that haven't been archived
+func (i *Inbox) CountActive() int {
+	ct := 0
+	for _, msg := range *i {
+		if !msg.Archived {
+			ct++
+		}
+	}
+	return ct
+}
+
+func (i *Inbox) CountArchived() int {
+	ct := 0
+	for _, msg := range *i {
+		if msg.Archived {
+			ct++
+		}
+	}
+	return ct
+}
+
+// Whether players can still send letters to this inbox
+func (i *Inbox) IsFull() bool {
+	return i.CountActive() >= int(configs.GetConfig().MailInboxSize)
+}
+
+func (i *Inbox) Get(idx int) (Message, bool) {
+	if idx < 0 || idx >= len(*i) {
+		return Message{}, false
+	}
+	return (*i)[idx], true
+}
+
+func (i *Inbox) Remove(idx int) {
+	if idx < 0 || idx >= len(*i) {
+		return
+	}
+	(*i) = append((*i)[:idx], (*i)[idx+1:]...)
+}
+
+// Removes everything but archived letters
 func (i *Inbox) Empty() {
-	(*i) = Inbox{}
+	kept := Inbox{}
+	for _, msg := range *i {
+		if msg.Archived {
+			kept = append(kept, msg)
+		}
+	}
+	(*i) = kept
+}
+
+// Whether the recipient still needs to pay for the attachments
+func (m Message) IsCODPending() bool {
+	return m.COD > 0 && (m.Item != nil || m.Gold > 0)
 }
 
 func (m Message) DateString() string {
 	tFormat := string(configs.GetConfig().TimeFormat)
 	return m.DateSent.Format(tFormat)
 }
+
+// How many more letters the user can send before hitting the hourly limit
+func (u *UserRecord) MailRemaining() int {
+
+	recent := []time.Time{}
+	for _, sent := range u.MailSent {
+		if time.Since(sent) < time.Hour {
+			recent = append(recent, sent)
+		}
+	}
+	u.MailSent = recent
+
+	return int(configs.GetConfig().MailSendLimit) - len(u.MailSent)
+}
+
+func (u *UserRecord) TrackMailSent() {
+	u.MailSent = append(u.MailSent, time.Now())
+}
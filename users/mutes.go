@@ -0,0 +1,32 @@
+package users
+
+import "time"
+
+// Mutes the user until some time from now.
+// A mute with no time limit, or one that runs longer, is left alone.
+func (u *UserRecord) MuteFor(d time.Duration) {
+
+	until := time.Now().Add(d)
+
+	if u.Muted && (u.MutedUntil.IsZero() || u.MutedUntil.After(until)) {
+		return
+	}
+
+	u.Muted = true
+	u.MutedUntil = until
+}
+
+// Mutes or unmutes the user with no time limit
+func (u *UserRecord) SetMuted(muted bool) {
+	u.Muted = muted
+	u.MutedUntil = time.Time{}
+}
+
+// Lifts a timed mute that has run out. Returns true if it was lifted.
+func (u *UserRecord) TryUnmute() bool {
+	if !u.Muted || u.MutedUntil.IsZero() || time.Now().Before(u.MutedUntil) {
+		return false
+	}
+	u.SetMuted(false)
+	return true
+}
@@ -10,4 +10,5 @@ type OnlineInfo struct {
 	OnlineTimeStr string
 	IsAFK         bool
 	Permission    string
+	Title         string
 }
@@ -0,0 +1,38 @@
+package users
+
+import (
+	"time"
+
+	"github.com/volte6/gomud/configs"
+)
+
+// A tell sent while the player was offline, waiting to be delivered
+type QueuedTell struct {
+	FromUserId int       `yaml:"fromuserid"`
+	FromName   string    `yaml:"fromname"`
+	Message    string    `yaml:"message"`
+	Sent       time.Time `yaml:"sent"`
+}
+
+// Returns false if there are already too many tells waiting
+func (u *UserRecord) QueueTell(t QueuedTell) bool {
+
+	if len(u.QueuedTells) >= int(configs.GetConfig().TellQueueSize) {
+		return false
+	}
+
+	if t.Sent.IsZero() {
+		t.Sent = time.Now()
+	}
+
+	u.QueuedTells = append(u.QueuedTells, t)
+
+	return true
+}
+
+// Returns the tells waiting for the user, and clears them
+func (u *UserRecord) TakeQueuedTells() []QueuedTell {
+	tells := u.QueuedTells
+	u.QueuedTells = nil
+	return tells
+}
@@ -13,9 +13,14 @@ import (
 	"github.com/volte6/gomud/characters"
 	"github.com/volte6/gomud/configs"
 	"github.com/volte6/gomud/connections"
+	"github.com/volte6/gomud/editor"
 	"github.com/volte6/gomud/events"
+	"github.com/volte6/gomud/factions"
 	"github.com/volte6/gomud/gametime"
+	"github.com/volte6/gomud/items"
+	"github.com/volte6/gomud/parties"
 	"github.com/volte6/gomud/prompt"
+	"github.com/volte6/gomud/quests"
 	"github.com/volte6/gomud/skills"
 	"github.com/volte6/gomud/term"
 	"github.com/volte6/gomud/util"
@@ -47,8 +52,17 @@ type UserRecord struct {
 	RoomMemoryBlob string                `yaml:"roommemoryblob,omitempty"`
 	ConfigOptions  map[string]any        `yaml:"configoptions,omitempty"`
 	Inbox          Inbox                 `yaml:"inbox,omitempty"`
-	Muted          bool                  `yaml:"muted,omitempty"`    // Cannot SEND custom communications to anyone but admin/mods
-	Deafened       bool                  `yaml:"deafened,omitempty"` // Cannot HEAR custom communications from anyone but admin/mods
+	Muted          bool                  `yaml:"muted,omitempty"`      // Cannot SEND custom communications to anyone but admin/mods
+	Deafened       bool                  `yaml:"deafened,omitempty"`   // Cannot HEAR custom communications from anyone but admin/mods
+	MailSent       []time.Time           `yaml:"mailsent,omitempty"`   // When recent letters were sent, for rate limiting
+	Channels       map[string]string     `yaml:"channels,omitempty"`   // Whether each chat channel was joined, left or muted. Unlisted channels use their default
+	MutedOn        []string              `yaml:"mutedon,omitempty"`    // Cannot SEND on these chat channels
+	DeafenedOn     []string              `yaml:"deafenedon,omitempty"` // Cannot HEAR these chat channels
+	Friends        []int                 `yaml:"friends,omitempty"`    // UserIds of friends, who they are told about logging in or out
+	Ignoring       []int                 `yaml:"ignoring,omitempty"`   // UserIds of players whose communications they don't receive
+	LastSeen       time.Time             `yaml:"lastseen,omitempty"`   // When they last logged in or out
+	QueuedTells    []QueuedTell          `yaml:"tells,omitempty"`      // Tells sent while they were offline
+	MutedUntil     time.Time             `yaml:"muteduntil,omitempty"` // If set, when Muted is lifted on its own
 	connectionId   uint64
 	unsentText     string
 	suggestText    string
@@ -56,7 +70,9 @@ type UserRecord struct {
 	lastInputRound uint64
 	tempDataStore  map[string]any
 	activePrompt   *prompt.Prompt
-	isZombie       bool // are they a zombie currently?
+	activeEditor   *editor.Editor // Taking over their input to answer a multi-line prompt question
+	editorConnId   uint64         // Connection the editor is taking input from
+	isZombie       bool           // are they a zombie currently?
 }
 
 func NewUserRecord(userId int, connectionId uint64) *UserRecord {
@@ -146,6 +162,96 @@ func (u *UserRecord) AddBuff(buffId int) {
 
 }
 
+// Takes a branch at the current step of a quest, and moves the story along it.
+func (u *UserRecord) ChooseQuestBranch(questId int, branchId string) bool {
+
+	branch, droppedQuestIds, ok := u.Character.ChooseQuestBranch(questId, branchId)
+	if !ok {
+		return false
+	}
+
+	for _, droppedId := range droppedQuestIds {
+		if questInfo := quests.GetQuest(quests.PartsToToken(droppedId, `all+`)); questInfo != nil && !questInfo.Secret {
+			u.SendText(fmt.Sprintf(`Your choice means you can no longer complete the quest: <ansi fg="questname">%s</ansi>`, questInfo.Name))
+		}
+	}
+
+	for factionId, amount := range branch.Reputation {
+		u.AdjustReputation(factionId, amount)
+	}
+
+	if branch.QuestId != `` {
+		events.AddToQueue(events.Quest{
+			UserId:     u.UserId,
+			QuestToken: branch.QuestId,
+		})
+	}
+
+	return true
+}
+
+// Returns everything carried by party members in the same room.
+// These count toward quests that ask the party to collect items, but only while someone still holds them.
+func (u *UserRecord) GetPartyItems() []items.Item {
+
+	partyItems := []items.Item{}
+
+	party := parties.Get(u.UserId)
+	if party == nil {
+		return partyItems
+	}
+
+	for _, memberId := range party.GetMembers() {
+		if memberId == u.UserId {
+			continue
+		}
+		if member := GetByUserId(memberId); member != nil && member.Character.RoomId == u.Character.RoomId {
+			partyItems = append(partyItems, member.Character.Items...)
+		}
+	}
+
+	return partyItems
+}
+
+// Changes the user's reputation with a faction and lets them know about it.
+func (u *UserRecord) AdjustReputation(factionId string, amount int) {
+
+	f := factions.GetFaction(factionId)
+	if f == nil || amount == 0 {
+		return
+	}
+
+	before, after := u.Character.AdjustReputation(f.FactionId, amount)
+
+	if amount > 0 {
+		u.SendText(fmt.Sprintf(`Your reputation with <ansi fg="factionname">%s</ansi> has increased.`, f.Name))
+	} else {
+		u.SendText(fmt.Sprintf(`Your reputation with <ansi fg="factionname">%s</ansi> has decreased.`, f.Name))
+	}
+
+	if before != after {
+		u.SendText(fmt.Sprintf(`You are now <ansi fg="%s">%s</ansi> with <ansi fg="factionname">%s</ansi>.`, after.Color(), after, f.Name))
+	}
+}
+
+// Adds gold to what the user is carrying, counting it toward their lifetime earnings.
+// Only use this for gold that enters the game, not gold passed between players.
+func (u *UserRecord) EarnGold(amount int) {
+
+	u.Character.Gold += amount
+
+	u.AddGoldEarned(amount)
+}
+
+// Counts gold toward the user's lifetime earnings without giving them any
+func (u *UserRecord) AddGoldEarned(amount int) {
+
+	if amount > 0 {
+		u.Character.GoldEarned += amount
+		events.AddToQueue(events.Achievement{UserId: u.UserId})
+	}
+}
+
 func (u *UserRecord) SendText(txt string) {
 
 	events.AddToQueue(events.Message{
@@ -561,6 +667,7 @@ func (u *UserRecord) GetPrompt() *prompt.Prompt {
 func (u *UserRecord) ClearPrompt() {
 
 	u.activePrompt = nil
+	u.closeEditor()
 }
 
 func (u *UserRecord) GetOnlineInfo() OnlineInfo {
@@ -601,5 +708,6 @@ func (u *UserRecord) GetOnlineInfo() OnlineInfo {
 		timeStr,
 		isAfk,
 		u.Permission,
+		u.Character.Title,
 	}
 }
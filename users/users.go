@@ -228,6 +228,8 @@ func LoginUser(u *UserRecord, connectionId connections.ConnectionId) (*UserRecor
 	// Set their input round to current to track idle time fresh
 	u.SetLastInputRound(util.GetRoundCount())
 
+	u.LastSeen = time.Now()
+
 	u.connectionId = connectionId
 
 	userManager.Users[u.UserId] = u
@@ -288,6 +290,7 @@ func LogOutUserByConnectionId(connectionId connections.ConnectionId) error {
 
 		// Make sure the user data is saved to a file.
 		if u != nil {
+			u.LastSeen = time.Now()
 			u.Character.Validate()
 			SaveUser(*u)
 		}
@@ -386,6 +389,23 @@ func LoadUser(username string) (*UserRecord, error) {
 // Stops searching if false is returned.
 func SearchOfflineUsers(searchFunc func(u *UserRecord) bool) {
 
+	SearchUserFiles(func(u *UserRecord) bool {
+
+		// If this is an online user, skip it
+		if _, ok := userManager.Usernames[u.Username]; ok {
+			return true
+		}
+
+		return searchFunc(u)
+	})
+
+}
+
+// Loads every saved user record, online or not, and runs against a function.
+// Stops searching if false is returned.
+// Doesn't touch the online users, so it's safe to run outside of the main loop.
+func SearchUserFiles(searchFunc func(u *UserRecord) bool) {
+
 	basePath := util.FilePath(string(configs.GetConfig().FolderUserData))
 
 	filepath.Walk(basePath, func(path string, info os.FileInfo, err error) error {
@@ -417,11 +437,6 @@ func SearchOfflineUsers(searchFunc func(u *UserRecord) bool) {
 				return err
 			}
 
-			// If this is an online user, skip it
-			if _, ok := userManager.Usernames[uRecord.Username]; ok {
-				return nil
-			}
-
 			if res := searchFunc(&uRecord); !res {
 				return errors.New(`done searching`)
 			}
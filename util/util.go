@@ -212,6 +212,27 @@ func SplitStringNL(input string, lineWidth int, nlPrefix ...string) string {
 	return output.String()
 }
 
+// Like SplitStringNL, but keeps any line breaks already in the input, such as between paragraphs
+func SplitLinesNL(input string, lineWidth int, nlPrefix ...string) string {
+
+	output := strings.Builder{}
+
+	linePrefix := ""
+	if len(nlPrefix) > 0 {
+		linePrefix = nlPrefix[0]
+	}
+
+	for i, line := range strings.Split(strings.ReplaceAll(input, "\r\n", "\n"), "\n") {
+		if i > 0 {
+			output.WriteString(term.CRLFStr)
+			output.WriteString(linePrefix)
+		}
+		output.WriteString(SplitStringNL(line, lineWidth, linePrefix))
+	}
+
+	return output.String()
+}
+
 func SplitButRespectQuotes(s string) []string {
 
 	// This regex matches either a quoted string (with either single or double quotes) or a non-space sequence.
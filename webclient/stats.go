@@ -3,6 +3,7 @@ package webclient
 import (
 	"sync"
 
+	"github.com/volte6/gomud/leaderboards"
 	"github.com/volte6/gomud/users"
 )
 
@@ -10,6 +11,7 @@ type Stats struct {
 	OnlineUsers   []users.OnlineInfo
 	TelnetPorts   []int
 	WebSocketPort int
+	Leaderboards  []leaderboards.Board
 }
 
 var (
@@ -18,6 +20,7 @@ var (
 		WebSocketPort: 0,
 		OnlineUsers:   []users.OnlineInfo{},
 		TelnetPorts:   []int{},
+		Leaderboards:  []leaderboards.Board{},
 	}
 )
 
@@ -41,4 +44,5 @@ func (s *Stats) Reset() {
 	s.WebSocketPort = 0
 	s.OnlineUsers = []users.OnlineInfo{}
 	s.TelnetPorts = []int{}
+	s.Leaderboards = []leaderboards.Board{}
 }
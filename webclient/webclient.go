@@ -3,6 +3,7 @@ package webclient
 import (
 	"context"
 	"fmt"
+	"html"
 	"log"
 	"log/slog"
 	"net/http"
@@ -13,6 +14,7 @@ import (
 
 	"github.com/gorilla/websocket"
 	"github.com/volte6/gomud/configs"
+	"github.com/volte6/gomud/discord"
 )
 
 var (
@@ -35,6 +37,7 @@ func Listen(webPort int, wg *sync.WaitGroup, webSocketHandler func(*websocket.Co
 	httpServer = &http.Server{Addr: fmt.Sprintf(`:%d`, webPort)}
 	http.HandleFunc("/", serveHome)
 	http.HandleFunc("/client", serveClient)
+	http.HandleFunc(discord.RelayPath, discord.HandleRelay)
 	http.HandleFunc("/ws", func(w http.ResponseWriter, r *http.Request) {
 
 		conn, err := upgrader.Upgrade(w, r, nil)
@@ -121,10 +124,33 @@ func serveHome(w http.ResponseWriter, r *http.Request) {
 
 	strB.WriteString("<p>&nbsp;</p>\n")
 
+	strB.WriteString("<h3>Leaderboards: </h3>\n")
+
+	for _, board := range stats.Leaderboards {
+
+		if len(board.Entries) == 0 {
+			continue
+		}
+
+		strB.WriteString(fmt.Sprintf("<h4>%s</h4>\n", html.EscapeString(board.Title)))
+		strB.WriteString("<table border=\"1\" cellspacing=\"0\" cellpadding=\"3\">\n")
+		strB.WriteString("<tr><th>#</th><th>Character</th><th>" + html.EscapeString(board.Title) + "</th></tr>\n")
+		for i, entry := range board.Entries {
+			strB.WriteString(fmt.Sprintf(`<tr><td align="right">%d.</td><td align="center"><b>%s</b></td><td align="center">%s</td></tr>`+"\n",
+				i+1,
+				html.EscapeString(entry.CharacterName),
+				html.EscapeString(entry.Details),
+			))
+		}
+		strB.WriteString("</table>\n")
+	}
+
+	strB.WriteString("<p>&nbsp;</p>\n")
+
 	strB.WriteString("<h3>Server Config: </h1>\n")
 
 	// exclude port, seed, and filepath info from webpage
-	allConfigData := configs.GetConfig().AllConfigData(`*port`, `seed`, `folder*`, `file*`)
+	allConfigData := configs.GetConfig().AllConfigData(`*port`, `seed`, `folder*`, `file*`, `discord*`)
 
 	// Extract keys into a slice
 	keys := make([]string, 0, len(allConfigData))
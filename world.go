@@ -11,15 +11,20 @@ import (
 	"sync"
 	"time"
 
+	"github.com/volte6/gomud/achievements"
 	"github.com/volte6/gomud/badinputtracker"
 	"github.com/volte6/gomud/buffs"
+	"github.com/volte6/gomud/channels"
 	"github.com/volte6/gomud/characters"
 	"github.com/volte6/gomud/colorpatterns"
 	"github.com/volte6/gomud/configs"
 	"github.com/volte6/gomud/connections"
+	"github.com/volte6/gomud/discord"
 	"github.com/volte6/gomud/events"
 	"github.com/volte6/gomud/items"
 	"github.com/volte6/gomud/keywords"
+	"github.com/volte6/gomud/leaderboards"
+	"github.com/volte6/gomud/loot"
 	"github.com/volte6/gomud/mobcommands"
 	"github.com/volte6/gomud/mobs"
 	"github.com/volte6/gomud/parties"
@@ -153,12 +158,28 @@ func (w *World) enterWorld(userId int, roomId int) {
 		)
 	}
 
+	channels.SendGMCPList(user)
+
+	// If they reconnected while writing something, pick up where they left off
+	user.SyncEditor()
+
+	notifyFriends(user, `has logged in.`)
+
 	w.UpdateStats()
 
 	// Pu thtme in the room
 	rooms.MoveToRoom(userId, roomId, true)
 }
 
+// Tells anyone who has the user on their friends list what they are up to
+func notifyFriends(user *users.UserRecord, what string) {
+	for _, u := range users.GetAllActiveUsers() {
+		if u.UserId != user.UserId && u.IsFriend(user.UserId) {
+			u.SendText(fmt.Sprintf(`<ansi fg="black-bold">(friend)</ansi> <ansi fg="username">%s</ansi> %s`, user.Character.Name, what))
+		}
+	}
+}
+
 func (w *World) leaveWorld(userId int) {
 
 	user := users.GetByUserId(userId)
@@ -183,6 +204,8 @@ func (w *World) leaveWorld(userId int) {
 	if _, ok := room.RemovePlayer(userId); ok {
 		tplTxt, _ := templates.Process("player-despawn", user.Character.Name)
 		room.SendText(tplTxt)
+
+		notifyFriends(user, `has logged out.`)
 	}
 
 	//
@@ -298,7 +321,7 @@ func (w *World) GetAutoComplete(userId int, inputText string) []string {
 				}
 			}
 
-		} else if cmd == `drop` || cmd == `trash` || cmd == `sell` || cmd == `store` || cmd == `inspect` || cmd == `enchant` || cmd == `appraise` || cmd == `give` {
+		} else if cmd == `drop` || cmd == `trash` || cmd == `sell` || cmd == `store` || cmd == `inspect` || cmd == `enchant` || cmd == `appraise` || cmd == `give` || cmd == `repair` {
 
 			itemList = user.Character.GetAllBackpackItems()
 
@@ -615,6 +638,7 @@ const (
 	roomMaintenancePeriod = time.Second * 3  // Every 3 seconds run room maintenance.
 	serverStatsLogPeriod  = time.Second * 60 // Every 60 seconds log server stats.
 	ansiAliasReloadPeriod = time.Second * 4  // Every 4 seconds reload ansi aliases.
+	leaderboardPeriod     = time.Minute * 15 // Every 15 minutes rebuild the leaderboards.
 )
 
 func (w *World) MainWorker(shutdown chan bool, wg *sync.WaitGroup) {
@@ -634,6 +658,11 @@ func (w *World) MainWorker(shutdown chan bool, wg *sync.WaitGroup) {
 	messageTimer := time.NewTimer(time.Millisecond)
 	turnTimer := time.NewTimer(time.Duration(c.TurnMs) * time.Millisecond)
 	statsTimer := time.NewTimer(time.Duration(10) * time.Second)
+	leaderboardTimer := time.NewTimer(leaderboardPeriod)
+
+	// Offline characters are included, so build it once up front rather than on demand.
+	// It's built in the background, so this doesn't hold anything up.
+	leaderboards.Update()
 
 loop:
 	for {
@@ -656,9 +685,16 @@ loop:
 
 			statsTimer.Reset(time.Duration(10) * time.Second)
 
+		case <-leaderboardTimer.C:
+			slog.Debug(`MainWorker`, `action`, `leaderboards.Update()`)
+			leaderboards.Update()
+			leaderboardTimer.Reset(leaderboardPeriod)
+
 		case <-roomUpdateTimer.C:
 			slog.Debug(`MainWorker`, `action`, `rooms.RoomMaintenance()`)
 			rooms.RoomMaintenance()
+			rooms.CollectRent()
+			rooms.MoveVehicles()
 			roomUpdateTimer.Reset(roomMaintenancePeriod)
 
 		case <-ansiAliasTimer.C:
@@ -737,12 +773,21 @@ func (w *World) processInput(userId int, inputText string) {
 
 		if activeQuestion = cmdPrompt.GetNextQuestion(); activeQuestion != nil {
 
-			activeQuestion.Answer(string(inputText))
-			inputText = ``
+			// Multi-line questions are only answered by the line editor.
+			// Anything else that comes in while they're writing is run as usual.
+			answer, answered := string(inputText), true
+			if activeQuestion.IsMultiLine() {
+				answer, answered = user.EditorText()
+			}
+
+			if answered {
+				activeQuestion.Answer(answer)
+				inputText = ``
 
-			// set the input buffer to invoke the command prompt it was relevant to
-			if cmdPrompt.Command != `` {
-				inputText = cmdPrompt.Command + " " + cmdPrompt.Rest
+				// set the input buffer to invoke the command prompt it was relevant to
+				if cmdPrompt.Command != `` {
+					inputText = cmdPrompt.Command + " " + cmdPrompt.Rest
+				}
 			}
 		} else {
 			// If a prompt was found, but no pending questions, clear it.
@@ -817,6 +862,9 @@ func (w *World) processInput(userId int, inputText string) {
 		}
 	}
 
+	// Whatever ran may have asked a multi-line question, or moved on from one
+	user.SyncEditor()
+
 	if connections.IsWebsocket(connId) {
 		connections.SendTo([]byte(user.GetCommandPrompt(true)), connId)
 	} else {
@@ -892,7 +940,10 @@ func (w *World) MessageTick() {
 				slog.Error("Event", "Type", "GMCPOut", "data", gmcp.Payload, "error", err)
 				continue
 			}
-			connections.SendTo([]byte(payload), user.ConnectionId())
+			if gmcp.Module != `` {
+				payload = append([]byte(gmcp.Module+` `), payload...)
+			}
+			connections.SendTo(term.GmcpPayload.BytesWithPayload(payload), user.ConnectionId())
 		}
 
 	}
@@ -924,6 +975,25 @@ func (w *World) MessageTick() {
 		)
 	}
 
+	//
+	// Chat channel messages from outside the game
+	//
+	eq = events.GetQueue(events.ChannelRelay{})
+	for eq.Len() > 0 {
+
+		e := eq.Poll().(events.Event)
+
+		relay, typeOk := e.(events.ChannelRelay)
+		if !typeOk {
+			slog.Error("Event", "Expected Type", "ChannelRelay", "Actual Type", e.Type())
+			continue
+		}
+
+		if ch := channels.Get(relay.ChannelId); ch != nil {
+			ch.SendAs(relay.Name, relay.Text)
+		}
+	}
+
 	redrawPrompts := make(map[uint64]string)
 
 	eq = events.GetQueue(events.WebClientCommand{})
@@ -972,6 +1042,10 @@ func (w *World) MessageTick() {
 					continue
 				}
 
+				if message.FromUserId > 0 && user.IsIgnoring(message.FromUserId) {
+					continue
+				}
+
 				if connections.IsWebsocket(user.ConnectionId()) {
 					connections.SendTo([]byte(term.AnsiMoveCursorColumn.String()+term.AnsiEraseLine.String()+message.Text), user.ConnectionId())
 					if _, ok := redrawPrompts[user.ConnectionId()]; !ok {
@@ -1022,6 +1096,10 @@ func (w *World) MessageTick() {
 						continue
 					}
 
+					if message.FromUserId > 0 && user.IsIgnoring(message.FromUserId) {
+						continue
+					}
+
 					// If this is a quiet message, make sure the player can hear it
 					if message.IsQuiet {
 						if !user.Character.HasBuffFlag(buffs.SuperHearing) {
@@ -1083,6 +1161,8 @@ func (w *World) UpdateStats() {
 
 	s.WebSocketPort = int(c.WebPort)
 
+	s.Leaderboards = leaderboards.GetAll()
+
 	webclient.UpdateStats(s)
 }
 
@@ -1488,6 +1568,54 @@ func (w *World) TurnTick() {
 
 	}
 
+	//
+	// Handle Quest Objective Queue
+	//
+	eq = events.GetQueue(events.QuestObjective{})
+	for eq.Len() > 0 {
+
+		e := eq.Poll().(events.Event)
+
+		objective, typeOk := e.(events.QuestObjective)
+		if !typeOk {
+			slog.Error("Event", "Expected Type", "QuestObjective", "Actual Type", e.Type())
+			continue
+		}
+
+		questUser := users.GetByUserId(objective.UserId)
+		if questUser == nil {
+			continue
+		}
+
+		// Party members in the same room check their quests too, since items collected count for them while held.
+		// Kills are already credited to the whole party when the mob dies.
+		if !objective.Shared && objective.ObjectiveType == string(quests.ObjectiveCollect) {
+			if questParty := parties.Get(questUser.UserId); questParty != nil {
+				for _, memberId := range questParty.GetMembers() {
+					if memberId == questUser.UserId {
+						continue
+					}
+					if member := users.GetByUserId(memberId); member != nil && member.Character.RoomId == questUser.Character.RoomId {
+						sharedObjective := objective
+						sharedObjective.UserId = memberId
+						sharedObjective.Shared = true
+						events.AddToQueue(sharedObjective)
+					}
+				}
+			}
+		}
+
+		questIds := questUser.Character.RecordQuestObjective(quests.ObjectiveType(objective.ObjectiveType), objective.MobId, objective.ItemId, objective.RoomId)
+		for _, questId := range questIds {
+			if nextToken, ok := questUser.Character.GetCompletedQuestStep(questId, questUser.GetPartyItems()...); ok {
+				events.AddToQueue(events.Quest{
+					UserId:     questUser.UserId,
+					QuestToken: nextToken,
+				})
+			}
+		}
+	}
+
 	//
 	// Handle Quest Queue
 	//
@@ -1543,6 +1671,11 @@ func (w *World) TurnTick() {
 				questUser.SendText(questUpTxt)
 			}
 
+			events.AddToQueue(events.Achievement{
+				UserId:       questUser.UserId,
+				CriteriaType: string(achievements.CriteriaQuests),
+			})
+
 			// Message to player?
 			if len(questInfo.Rewards.PlayerMessage) > 0 {
 				questUser.SendText(questInfo.Rewards.PlayerMessage)
@@ -1565,7 +1698,7 @@ func (w *World) TurnTick() {
 			// Gold reward?
 			if questInfo.Rewards.Gold > 0 {
 				questUser.SendText(fmt.Sprintf(`You receive <ansi fg="gold">%d gold</ansi>!`, questInfo.Rewards.Gold))
-				questUser.Character.Gold += questInfo.Rewards.Gold
+				questUser.EarnGold(questInfo.Rewards.Gold)
 			}
 			// Item reward?
 			if questInfo.Rewards.ItemId > 0 {
@@ -1583,6 +1716,27 @@ func (w *World) TurnTick() {
 
 				}
 			}
+			// Loot reward?
+			if questInfo.Rewards.LootTable != `` {
+				drop := loot.Roll(questInfo.Rewards.LootTable, questUser.Character.Level)
+
+				if drop.Gold > 0 {
+					questUser.SendText(fmt.Sprintf(`You receive <ansi fg="gold">%d gold</ansi>!`, drop.Gold))
+					questUser.EarnGold(drop.Gold)
+				}
+
+				for _, newItm := range drop.Items {
+					questUser.SendText(fmt.Sprintf(`You receive <ansi fg="itemname">%s</ansi>!`, newItm.DisplayName()))
+					questUser.Character.StoreItem(newItm)
+
+					if iSpec := newItm.GetSpec(); iSpec.QuestToken != `` {
+						events.AddToQueue(events.Quest{
+							UserId:     questUser.UserId,
+							QuestToken: iSpec.QuestToken,
+						})
+					}
+				}
+			}
 			// Buff reward?
 			if questInfo.Rewards.BuffId > 0 {
 
@@ -1629,6 +1783,10 @@ func (w *World) TurnTick() {
 
 				}
 			}
+			// Reputation reward?
+			for factionId, amount := range questInfo.Rewards.Reputation {
+				questUser.AdjustReputation(factionId, amount)
+			}
 			// Move them to another room/area?
 			if questInfo.Rewards.RoomId > 0 {
 				questUser.SendText(`You are suddenly moved to a new place!`)
@@ -1646,6 +1804,58 @@ func (w *World) TurnTick() {
 			}
 		}
 
+		// They may already have what the new step asks for, such as items to collect
+		questId, _ := quests.TokenToParts(quest.QuestToken)
+		if nextToken, ok := questUser.Character.GetCompletedQuestStep(questId, questUser.GetPartyItems()...); ok {
+			events.AddToQueue(events.Quest{
+				UserId:     questUser.UserId,
+				QuestToken: nextToken,
+			})
+		}
+
+		// If the story branches here, let the player choose which way it goes
+		if step, ok := questUser.Character.GetPendingQuestBranches(questId); ok && step.BranchPrompt != `` {
+			if questUser.GetPrompt() == nil {
+				questUser.Command(fmt.Sprintf(`quests choose %d`, questId))
+			} else {
+				questUser.SendText(fmt.Sprintf(`A choice awaits! Type <ansi fg="command">quests choose %d</ansi> to decide.`, questId))
+			}
+		}
+
+	}
+
+	//
+	// Handle Achievement Queue
+	//
+	eq = events.GetQueue(events.Achievement{})
+	for eq.Len() > 0 {
+
+		e := eq.Poll().(events.Event)
+
+		achievement, typeOk := e.(events.Achievement)
+		if !typeOk {
+			slog.Error("Event", "Expected Type", "Achievement", "Actual Type", e.Type())
+			continue
+		}
+
+		achievementUser := users.GetByUserId(achievement.UserId)
+		if achievementUser == nil {
+			continue
+		}
+
+		for _, unlocked := range achievements.Check(achievementUser, achievements.CriteriaType(achievement.CriteriaType)) {
+
+			achievementTxt, _ := templates.Process("character/achievementup", unlocked)
+			achievementUser.SendText(achievementTxt)
+
+			if unlocked.Rare {
+				events.AddToQueue(events.Broadcast{
+					Text: fmt.Sprintf(`<ansi fg="magenta-bold">*</ansi> <ansi fg="username">%s</ansi> has unlocked the rare achievement <ansi fg="white-bold">%s</ansi>! <ansi fg="magenta-bold">*</ansi>`, achievementUser.Character.Name, unlocked.Name),
+				})
+
+				discord.Mirror(discord.MirrorAchievements, ``, fmt.Sprintf(`%s has unlocked the rare achievement %s!`, achievementUser.Character.Name, unlocked.Name))
+			}
+		}
 	}
 
 	//
@@ -14,15 +14,18 @@ import (
 	"github.com/volte6/gomud/combat"
 	"github.com/volte6/gomud/configs"
 	"github.com/volte6/gomud/connections"
+	"github.com/volte6/gomud/discord"
 	"github.com/volte6/gomud/events"
 	"github.com/volte6/gomud/gametime"
 	"github.com/volte6/gomud/items"
 	"github.com/volte6/gomud/mobs"
+	"github.com/volte6/gomud/pets"
 	"github.com/volte6/gomud/rooms"
 	"github.com/volte6/gomud/scripting"
 	"github.com/volte6/gomud/spells"
 	"github.com/volte6/gomud/templates"
 	"github.com/volte6/gomud/term"
+	"github.com/volte6/gomud/usercommands"
 	"github.com/volte6/gomud/users"
 	"github.com/volte6/gomud/util"
 )
@@ -242,6 +245,11 @@ func (w *World) handlePlayerRoundTicks() {
 				// Roundtick any cooldowns
 				user.Character.Cooldowns.RoundTick()
 
+				// Lift timed mutes that have run out
+				if user.TryUnmute() {
+					user.SendText(`You are no longer <ansi fg="alert-5">MUTED</ansi>.`)
+				}
+
 				if user.Character.Charmed != nil && user.Character.Charmed.RoundsRemaining > 0 {
 					user.Character.Charmed.RoundsRemaining--
 				}
@@ -682,8 +690,16 @@ func (w *World) handlePlayerCombat() (affectedPlayerIds []int, affectedMobInstan
 
 			var roundResult combat.AttackResult
 
+			defHealthBefore := defUser.Character.Health
+
 			roundResult = combat.AttackPlayerVsPlayer(user, defUser)
 
+			// Cutting down another player counts toward PvP kills, unless it's just an arena bout
+			if defHealthBefore > 0 && defUser.Character.Health <= 0 && !defRoom.IsArena {
+				user.Character.KD.AddPlayerKill()
+				defUser.Character.KD.AddPlayerDeath()
+			}
+
 			// If a mob attacks a player, check whether player has a charmed mob helping them, and if so, they will move to attack back
 			room := rooms.LoadRoom(roomId)
 			for _, instanceId := range room.GetMobs(rooms.FindCharmed) {
@@ -739,29 +755,13 @@ func (w *World) handlePlayerCombat() (affectedPlayerIds []int, affectedMobInstan
 
 			// If the attack connected, check for damage to equipment.
 			if roundResult.Hit {
-				// For now, only focus on offhand items.
-				if defUser.Character.Equipment.Offhand.ItemId > 0 {
+				// Weapons wear from use, and armor from being struck
+				w.handleBrokenItems(user, uRoom, user.Character.WearWeapons(roundResult.Crit))
+				w.handleBrokenItems(defUser, defRoom, defUser.Character.WearArmor(roundResult.Crit))
 
-					modifier := 0
-					if roundResult.Crit { // Crits double the chance of breakage for offhand items.
-						modifier = int(defUser.Character.Equipment.Offhand.GetSpec().BreakChance)
-					}
-
-					if defUser.Character.Equipment.Offhand.BreakTest(modifier) {
-						// Send message about the break
-
-						defUser.SendText(`<ansi fg="202">***</ansi>`)
-						defUser.SendText(fmt.Sprintf(`<ansi fg="214"><ansi fg="202">***</ansi> Your <ansi fg="item">%s</ansi> breaks! <ansi fg="202">***</ansi></ansi>`, defUser.Character.Equipment.Offhand.NameSimple()))
-						defUser.SendText(`<ansi fg="202">***</ansi>`)
-
-						defRoom.SendText(fmt.Sprintf(`<ansi fg="214"><ansi fg="202">***</ansi> The <ansi fg="item">%s</ansi> <ansi fg="username">%s</ansi> was carrying breaks! <ansi fg="202">***</ansi></ansi>`, defUser.Character.Equipment.Offhand.NameSimple(), defUser.Character.Name), defUser.UserId)
-
-						defUser.Character.RemoveFromBody(defUser.Character.Equipment.Offhand)
-						itm := items.New(20) // Broken item
-						if !defUser.Character.StoreItem(itm) {
-							room.AddItem(itm, false)
-						}
-					}
+				// Mounts can get caught up in the fight
+				if defUser.Character.Pet.IsRidden() {
+					w.handleMountDamage(defUser, defRoom, roundResult.DamageToTarget)
 				}
 			}
 
@@ -891,6 +891,10 @@ func (w *World) handlePlayerCombat() (affectedPlayerIds []int, affectedMobInstan
 			// Handle any scripted behavior now.
 			if roundResult.Hit {
 				scripting.TryMobScriptEvent(`onHurt`, defMob.InstanceId, user.UserId, `user`, map[string]any{`damage`: roundResult.DamageToTarget, `crit`: roundResult.Crit})
+
+				// Weapons wear from use, and armor from being struck
+				w.handleBrokenItems(user, uRoom, user.Character.WearWeapons(roundResult.Crit))
+				w.handleBrokenMobItems(defMob, defRoom, defMob.Character.WearArmor(roundResult.Crit))
 			}
 
 			//
@@ -1189,29 +1193,13 @@ func (w *World) handleMobCombat() (affectedPlayerIds []int, affectedMobInstanceI
 			// If the attack connected, check for damage to equipment.
 			if roundResult.Hit {
 
-				// For now, only focus on offhand items.
-				if defUser.Character.Equipment.Offhand.ItemId > 0 {
-
-					modifier := 0
-					if roundResult.Crit { // Crits double the chance of breakage for offhand items.
-						modifier = int(defUser.Character.Equipment.Offhand.GetSpec().BreakChance)
-					}
-
-					if defUser.Character.Equipment.Offhand.BreakTest(modifier) {
-						// Send message about the break
+				// Weapons wear from use, and armor from being struck
+				w.handleBrokenMobItems(mob, mobRoom, mob.Character.WearWeapons(roundResult.Crit))
+				w.handleBrokenItems(defUser, defRoom, defUser.Character.WearArmor(roundResult.Crit))
 
-						defUser.SendText(`<ansi fg="202">***</ansi>`)
-						defUser.SendText(fmt.Sprintf(`<ansi fg="214"><ansi fg="202">***</ansi> Your <ansi fg="item">%s</ansi> breaks! <ansi fg="202">***</ansi></ansi>`, defUser.Character.Equipment.Offhand.NameSimple()))
-						defUser.SendText(`<ansi fg="202">***</ansi>`)
-
-						defRoom.SendText(fmt.Sprintf(`<ansi fg="214"><ansi fg="202">***</ansi> The <ansi fg="item">%s</ansi> <ansi fg="username">%s</ansi> was carrying breaks! <ansi fg="202">***</ansi></ansi>`, defUser.Character.Equipment.Offhand.NameSimple(), defUser.Character.Name), defUser.UserId)
-
-						defUser.Character.RemoveFromBody(defUser.Character.Equipment.Offhand)
-						itm := items.New(20) // Broken item
-						if !defUser.Character.StoreItem(itm) {
-							room.AddItem(itm, false)
-						}
-					}
+				// Mounts can get caught up in the fight
+				if defUser.Character.Pet.IsRidden() {
+					w.handleMountDamage(defUser, defRoom, roundResult.DamageToTarget)
 				}
 			}
 
@@ -1314,29 +1302,9 @@ func (w *World) handleMobCombat() (affectedPlayerIds []int, affectedMobInstanceI
 
 			// If the attack connected, check for damage to equipment.
 			if roundResult.Hit {
-				// For now, only focus on offhand items.
-				if defMob.Character.Equipment.Offhand.ItemId > 0 {
-
-					modifier := 0
-					if roundResult.Crit { // Crits double the chance of breakage for offhand items.
-						modifier = int(defMob.Character.Equipment.Offhand.GetSpec().BreakChance)
-					}
-
-					if defMob.Character.Equipment.Offhand.BreakTest(modifier) {
-						// Send message about the break
-
-						if defRoom := rooms.LoadRoom(defMob.Character.RoomId); defRoom != nil {
-
-							defRoom.SendText(fmt.Sprintf(`<ansi fg="214"><ansi fg="202">***</ansi> The <ansi fg="item">%s</ansi> <ansi fg="mobname">%s</ansi> was carrying breaks! <ansi fg="202">***</ansi></ansi>`, defMob.Character.Equipment.Offhand.NameSimple(), defMob.Character.Name))
-
-							defMob.Character.RemoveFromBody(defMob.Character.Equipment.Offhand)
-							itm := items.New(20) // Broken item
-							if !defMob.Character.StoreItem(itm) {
-								defRoom.AddItem(itm, false)
-							}
-						}
-					}
-				}
+				// Weapons wear from use, and armor from being struck
+				w.handleBrokenMobItems(mob, mobRoom, mob.Character.WearWeapons(roundResult.Crit))
+				w.handleBrokenMobItems(defMob, defRoom, defMob.Character.WearArmor(roundResult.Crit))
 			}
 
 			if mob.Character.Health <= 0 || defMob.Character.Health <= 0 {
@@ -1366,6 +1334,14 @@ func (w *World) handleAffected(affectedPlayerIds []int, affectedMobInstanceIds [
 
 		if user := users.GetByUserId(userId); user != nil {
 
+			if user.Character.Health < 1 {
+				// Nobody dies in an arena
+				if room := rooms.LoadRoom(user.Character.RoomId); room != nil && room.IsArena {
+					w.handleArenaDefeat(user, room)
+					continue
+				}
+			}
+
 			if user.Character.Health <= -10 {
 				user.Command(`suicide`) // suicide drops all money/items and transports to land of the dead.
 			} else if user.Character.Health < 1 {
@@ -1401,6 +1377,99 @@ func (w *World) handleAffected(affectedPlayerIds []int, affectedMobInstanceIds [
 
 }
 
+// Ends a fight in an arena without any of the usual death penalties.
+// If the fight was a duel, the ratings of both players are updated.
+func (w *World) handleArenaDefeat(user *users.UserRecord, room *rooms.Room) {
+
+	user.Character.Health = 1
+	user.Character.EndAggro()
+
+	// Call off anyone still fighting the loser
+	for _, uid := range room.GetPlayers() {
+		if u := users.GetByUserId(uid); u != nil && u.Character.Aggro != nil && u.Character.Aggro.UserId == user.UserId {
+			u.Character.EndAggro()
+		}
+	}
+	for _, mid := range room.GetMobs() {
+		if m := mobs.GetInstance(mid); m != nil && m.Character.Aggro != nil && m.Character.Aggro.UserId == user.UserId {
+			m.Character.EndAggro()
+		}
+	}
+
+	user.SendText(`<ansi fg="red">You have been defeated!</ansi> The arena attendants help you back to your feet.`)
+	room.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> <ansi fg="red">has been defeated!</ansi>`, user.Character.Name), user.UserId)
+
+	opponentId, _ := user.GetTempData(usercommands.DuelOpponentKey).(int)
+	if opponentId == 0 {
+		return
+	}
+
+	user.SetTempData(usercommands.DuelOpponentKey, nil)
+
+	winner := users.GetByUserId(opponentId)
+	if winner == nil {
+		return
+	}
+
+	winner.SetTempData(usercommands.DuelOpponentKey, nil)
+	winner.Character.EndAggro()
+
+	gained, lost := characters.RecordPvPResult(&winner.Character.PvP, &user.Character.PvP)
+
+	winner.SendText(fmt.Sprintf(`<ansi fg="yellow-bold">You won the duel!</ansi> Your rating rises by <ansi fg="green">%d</ansi> to <ansi fg="yellow">%d</ansi>.`, gained, winner.Character.PvP.GetRating()))
+	user.SendText(fmt.Sprintf(`<ansi fg="yellow-bold">You lost the duel.</ansi> Your rating falls by <ansi fg="red">%d</ansi> to <ansi fg="yellow">%d</ansi>.`, lost, user.Character.PvP.GetRating()))
+	room.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> <ansi fg="yellow-bold">has won the duel against</ansi> <ansi fg="username">%s</ansi>!`, winner.Character.Name, user.Character.Name), user.UserId, winner.UserId)
+
+}
+
+// Lets a player, and anyone watching, know their gear just broke
+func (w *World) handleBrokenItems(user *users.UserRecord, room *rooms.Room, brokenItems []items.Item) {
+	for _, itm := range brokenItems {
+
+		user.SendText(`<ansi fg="202">***</ansi>`)
+		user.SendText(fmt.Sprintf(`<ansi fg="214"><ansi fg="202">***</ansi> Your <ansi fg="item">%s</ansi> breaks! <ansi fg="202">***</ansi></ansi>`, itm.NameSimple()))
+		user.SendText(`<ansi fg="202">***</ansi>`)
+		user.SendText(`It will be useless until it is <ansi fg="command">repair</ansi>ed.`)
+
+		room.SendText(fmt.Sprintf(`<ansi fg="214"><ansi fg="202">***</ansi> The <ansi fg="item">%s</ansi> <ansi fg="username">%s</ansi> was carrying breaks! <ansi fg="202">***</ansi></ansi>`, itm.NameSimple(), user.Character.Name), user.UserId)
+	}
+}
+
+// Lets anyone watching know a mob's gear just broke
+func (w *World) handleBrokenMobItems(mob *mobs.Mob, room *rooms.Room, brokenItems []items.Item) {
+	for _, itm := range brokenItems {
+		room.SendText(fmt.Sprintf(`<ansi fg="214"><ansi fg="202">***</ansi> The <ansi fg="item">%s</ansi> <ansi fg="mobname">%s</ansi> was carrying breaks! <ansi fg="202">***</ansi></ansi>`, itm.NameSimple(), mob.Character.Name))
+	}
+}
+
+// Rolls to see whether a blow that hit a rider also wounds their mount.
+// If the mount dies, the rider is thrown and anything it carried falls to the ground.
+func (w *World) handleMountDamage(user *users.UserRecord, room *rooms.Room, damage int) {
+
+	// 1 in 4 blows catch the mount too
+	if util.Rand(4) != 0 {
+		return
+	}
+
+	mount := &user.Character.Pet
+
+	if !mount.TakeDamage(damage) {
+		user.SendText(fmt.Sprintf(`%s is caught by the blow and takes <ansi fg="damage">%d damage</ansi>!`, mount.DisplayName(), damage))
+		return
+	}
+
+	user.SendText(fmt.Sprintf(`<ansi fg="red">%s collapses beneath you and dies! You are thrown to the ground.</ansi>`, mount.DisplayName()))
+	room.SendText(fmt.Sprintf(`%s collapses and dies, throwing <ansi fg="username">%s</ansi> to the ground!`, mount.DisplayName(), user.Character.Name), user.UserId)
+
+	for _, itm := range mount.Items {
+		room.AddItem(itm, false)
+	}
+
+	user.Character.Pet = pets.Pet{}
+	// make sure the pet buffs are removed
+	user.Character.Validate(true)
+}
+
 // Idle Mobs
 func (w *World) handleIdleMobs() {
 
@@ -1511,6 +1580,8 @@ func (w *World) handleAutoHealing(roundNumber uint64) {
 				if user.Character.Health < user.Character.HealthMax.Value {
 					user.Character.Health++
 				}
+			} else if room := rooms.LoadRoom(user.Character.RoomId); room != nil && room.IsArena {
+				w.handleArenaDefeat(user, room)
 			} else {
 				if user.Character.Health <= -10 {
 
@@ -1673,6 +1744,8 @@ func (w *World) CheckForLevelUps() {
 					Text: fmt.Sprintf(`<ansi fg="magenta-bold">***</ansi> <ansi fg="username">%s</ansi> <ansi fg="yellow">has leveled up to level %d!</ansi> <ansi fg="magenta-bold">***</ansi>%s`, user.Character.Name, user.Character.Level, term.CRLFStr),
 				})
 
+				discord.Mirror(discord.MirrorLevelUps, ``, fmt.Sprintf(`%s has leveled up to level %d!`, user.Character.Name, user.Character.Level))
+
 				if user.Character.Level >= 5 {
 					for _, mobInstanceId := range user.Character.CharmedMobs {
 						if mob := mobs.GetInstance(mobInstanceId); mob != nil {
@@ -1725,6 +1798,16 @@ func (w *World) processAuction(tNow time.Time) {
 			}
 		}
 
+		if a.HighestBid > 0 {
+			winnerName := a.HighestBidderName
+			if a.Anonymous {
+				winnerName = `Anonymous`
+			}
+			discord.Mirror(discord.MirrorAuctions, ``, fmt.Sprintf(`The auction for the %s has ended. %s won it for %d gold.`, a.ItemData.NameComplex(), winnerName, a.HighestBid))
+		} else {
+			discord.Mirror(discord.MirrorAuctions, ``, fmt.Sprintf(`The auction for the %s has ended without a winner.`, a.ItemData.NameComplex()))
+		}
+
 		// Give the item to the winner and let them know
 		if a.HighestBidUserId > 0 {
 
@@ -1813,6 +1896,8 @@ func (w *World) processAuction(tNow time.Time) {
 			}
 		}
 
+		discord.Mirror(discord.MirrorAuctions, ``, fmt.Sprintf(`An auction has started for the %s. The minimum bid is %d gold.`, a.ItemData.NameComplex(), a.MinimumBid))
+
 	} else if time.Since(a.LastUpdate) > time.Second*time.Duration(c.AuctionUpdateSeconds) {
 
 		a.LastUpdate = tNow